@@ -130,13 +130,19 @@ func (i *beaconStatesCollector) addGenesisState(ctx context.Context, state *stat
 			return err
 		}
 		committeeSlot := i.beaconCfg.RoundSlotToSyncCommitteePeriod(slot)
-		committee := *state.CurrentSyncCommittee()
-		if err := i.currentSyncCommitteeCollector.Collect(base_encoding.Encode64ToBytes4(committeeSlot), libcommon.Copy(committee[:])); err != nil {
+		committee, err := state.CurrentSyncCommittee().EncodeSSZ(nil)
+		if err != nil {
+			return err
+		}
+		if err := i.currentSyncCommitteeCollector.Collect(base_encoding.Encode64ToBytes4(committeeSlot), committee); err != nil {
 			return err
 		}
 
-		committee = *state.NextSyncCommittee()
-		if err := i.nextSyncCommitteeCollector.Collect(base_encoding.Encode64ToBytes4(committeeSlot), libcommon.Copy(committee[:])); err != nil {
+		committee, err = state.NextSyncCommittee().EncodeSSZ(nil)
+		if err != nil {
+			return err
+		}
+		if err := i.nextSyncCommitteeCollector.Collect(base_encoding.Encode64ToBytes4(committeeSlot), committee); err != nil {
 			return err
 		}
 	}
@@ -231,12 +237,20 @@ func (i *beaconStatesCollector) collectFlattenedProposers(epoch uint64, proposer
 
 func (i *beaconStatesCollector) collectCurrentSyncCommittee(slot uint64, committee *solid.SyncCommittee) error {
 	roundedSlot := i.beaconCfg.RoundSlotToSyncCommitteePeriod(slot)
-	return i.currentSyncCommitteeCollector.Collect(base_encoding.Encode64ToBytes4(roundedSlot), committee[:])
+	encoded, err := committee.EncodeSSZ(nil)
+	if err != nil {
+		return err
+	}
+	return i.currentSyncCommitteeCollector.Collect(base_encoding.Encode64ToBytes4(roundedSlot), encoded)
 }
 
 func (i *beaconStatesCollector) collectNextSyncCommittee(slot uint64, committee *solid.SyncCommittee) error {
 	roundedSlot := i.beaconCfg.RoundSlotToSyncCommitteePeriod(slot)
-	return i.nextSyncCommitteeCollector.Collect(base_encoding.Encode64ToBytes4(roundedSlot), committee[:])
+	encoded, err := committee.EncodeSSZ(nil)
+	if err != nil {
+		return err
+	}
+	return i.nextSyncCommitteeCollector.Collect(base_encoding.Encode64ToBytes4(roundedSlot), encoded)
 }
 
 func (i *beaconStatesCollector) collectEth1DataVote(slot uint64, eth1Data *cltypes.Eth1Data) error {
@@ -82,7 +82,7 @@ func (b *BeaconResponse) EncodeSSZ(xs []byte) ([]byte, error) {
 	if !ok {
 		return nil, NewEndpointError(http.StatusBadRequest, ErrorSszNotSupported)
 	}
-	encoded, err := marshaler.EncodeSSZ(nil)
+	encoded, err := marshaler.EncodeSSZ(xs)
 	if err != nil {
 		return nil, err
 	}
@@ -169,7 +169,7 @@ func (a *ApiHandler) produceBeaconBody(ctx context.Context, apiVersion int, base
 	beaconBody := cltypes.NewBeaconBody(&clparams.MainnetBeaconConfig)
 	// Setup body.
 	beaconBody.RandaoReveal = randaoReveal
-	beaconBody.Graffiti = graffiti
+	beaconBody.Graffiti = cltypes.Graffiti(graffiti)
 	beaconBody.Version = stateVersion
 	// Sync aggregate is empty for now.
 	beaconBody.SyncAggregate = &cltypes.SyncAggregate{
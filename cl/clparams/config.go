@@ -687,19 +687,20 @@ var MainnetBeaconConfig BeaconChainConfig = BeaconChainConfig{
 	MaxValidatorsPerWithdrawalsSweep: 16384,
 
 	// BLS domain values.
-	DomainBeaconProposer:              utils.Uint32ToBytes4(0x00000000),
-	DomainBeaconAttester:              utils.Uint32ToBytes4(0x01000000),
-	DomainRandao:                      utils.Uint32ToBytes4(0x02000000),
-	DomainDeposit:                     utils.Uint32ToBytes4(0x03000000),
-	DomainVoluntaryExit:               utils.Uint32ToBytes4(0x04000000),
-	DomainSelectionProof:              utils.Uint32ToBytes4(0x05000000),
-	DomainAggregateAndProof:           utils.Uint32ToBytes4(0x06000000),
-	DomainSyncCommittee:               utils.Uint32ToBytes4(0x07000000),
-	DomainSyncCommitteeSelectionProof: utils.Uint32ToBytes4(0x08000000),
-	DomainContributionAndProof:        utils.Uint32ToBytes4(0x09000000),
-	DomainApplicationMask:             utils.Uint32ToBytes4(0x00000001),
-	DomainApplicationBuilder:          utils.Uint32ToBytes4(0x00000001),
-	DomainBLSToExecutionChange:        utils.Uint32ToBytes4(0x0A000000),
+	DomainBeaconProposer:              DomainTypeBeaconProposer,
+	DomainBeaconAttester:              DomainTypeBeaconAttester,
+	DomainRandao:                      DomainTypeRandao,
+	DomainDeposit:                     DomainTypeDeposit,
+	DomainVoluntaryExit:               DomainTypeVoluntaryExit,
+	DomainSelectionProof:              DomainTypeSelectionProof,
+	DomainAggregateAndProof:           DomainTypeAggregateAndProof,
+	DomainSyncCommittee:               DomainTypeSyncCommittee,
+	DomainSyncCommitteeSelectionProof: DomainTypeSyncCommitteeSelectionProof,
+	DomainContributionAndProof:        DomainTypeContributionAndProof,
+	DomainApplicationMask:             DomainTypeApplicationMask,
+	DomainApplicationBuilder:          DomainTypeApplicationBuilder,
+	DomainBLSToExecutionChange:        DomainTypeBLSToExecutionChange,
+	DomainBlobSideCar:                 DomainTypeBlobSidecar,
 
 	// Prysm constants.
 	ConfigName: "mainnet",
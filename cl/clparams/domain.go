@@ -0,0 +1,23 @@
+package clparams
+
+import libcommon "github.com/ledgerwatch/erigon-lib/common"
+
+// Domain types of the spec. They are the first 4 bytes of every signature domain and keep signatures of
+// different messages apart, e.g. an exit signature can never pass as an attestation one. The mainnet config
+// is built from them; code that verifies signatures reads the domain types from its BeaconChainConfig.
+var (
+	DomainTypeBeaconProposer              = libcommon.Bytes4{0x00, 0x00, 0x00, 0x00}
+	DomainTypeBeaconAttester              = libcommon.Bytes4{0x01, 0x00, 0x00, 0x00}
+	DomainTypeRandao                      = libcommon.Bytes4{0x02, 0x00, 0x00, 0x00}
+	DomainTypeDeposit                     = libcommon.Bytes4{0x03, 0x00, 0x00, 0x00}
+	DomainTypeVoluntaryExit               = libcommon.Bytes4{0x04, 0x00, 0x00, 0x00}
+	DomainTypeSelectionProof              = libcommon.Bytes4{0x05, 0x00, 0x00, 0x00}
+	DomainTypeAggregateAndProof           = libcommon.Bytes4{0x06, 0x00, 0x00, 0x00}
+	DomainTypeSyncCommittee               = libcommon.Bytes4{0x07, 0x00, 0x00, 0x00}
+	DomainTypeSyncCommitteeSelectionProof = libcommon.Bytes4{0x08, 0x00, 0x00, 0x00}
+	DomainTypeContributionAndProof        = libcommon.Bytes4{0x09, 0x00, 0x00, 0x00}
+	DomainTypeBLSToExecutionChange        = libcommon.Bytes4{0x0a, 0x00, 0x00, 0x00}
+	DomainTypeBlobSidecar                 = libcommon.Bytes4{0x0b, 0x00, 0x00, 0x00}
+	DomainTypeApplicationMask             = libcommon.Bytes4{0x00, 0x00, 0x00, 0x01}
+	DomainTypeApplicationBuilder          = libcommon.Bytes4{0x00, 0x00, 0x00, 0x01}
+)
@@ -0,0 +1,35 @@
+package clparams
+
+import (
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDomainTypes(t *testing.T) {
+	// Values of the consensus specs, as hex strings.
+	for expected, domainType := range map[string]libcommon.Bytes4{
+		"0x00000000": DomainTypeBeaconProposer,
+		"0x01000000": DomainTypeBeaconAttester,
+		"0x02000000": DomainTypeRandao,
+		"0x03000000": DomainTypeDeposit,
+		"0x04000000": DomainTypeVoluntaryExit,
+		"0x05000000": DomainTypeSelectionProof,
+		"0x06000000": DomainTypeAggregateAndProof,
+		"0x07000000": DomainTypeSyncCommittee,
+		"0x08000000": DomainTypeSyncCommitteeSelectionProof,
+		"0x09000000": DomainTypeContributionAndProof,
+		"0x0a000000": DomainTypeBLSToExecutionChange,
+		"0x0b000000": DomainTypeBlobSidecar,
+		"0x00000001": DomainTypeApplicationMask,
+	} {
+		require.Equal(t, expected, domainType.String())
+	}
+
+	require.Equal(t, DomainTypeBeaconProposer, MainnetBeaconConfig.DomainBeaconProposer)
+	require.Equal(t, DomainTypeDeposit, MainnetBeaconConfig.DomainDeposit)
+	require.Equal(t, DomainTypeVoluntaryExit, MainnetBeaconConfig.DomainVoluntaryExit)
+	require.Equal(t, DomainTypeSyncCommittee, MainnetBeaconConfig.DomainSyncCommittee)
+	require.Equal(t, DomainTypeBlobSidecar, MainnetBeaconConfig.DomainBlobSideCar)
+}
@@ -1,7 +1,10 @@
 package cltypes
 
 import (
+	"fmt"
+
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
 	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
 	"github.com/ledgerwatch/erigon/cl/merkle_tree"
 	ssz2 "github.com/ledgerwatch/erigon/cl/ssz"
@@ -30,6 +33,11 @@ func (a *AggregateAndProof) DecodeSSZ(buf []byte, version int) error {
 	return ssz2.UnmarshalSSZ(buf, version, &a.AggregatorIndex, a.Aggregate, a.SelectionProof[:])
 }
 
+func (a *AggregateAndProof) DecodeSSZStrict(buf []byte, version int) error {
+	a.Aggregate = new(solid.Attestation)
+	return ssz2.UnmarshalSSZStrict(buf, version, &a.AggregatorIndex, a.Aggregate, a.SelectionProof[:])
+}
+
 func (a *AggregateAndProof) EncodingSizeSSZ() int {
 	return 108 + a.Aggregate.EncodingSizeSSZ()
 }
@@ -52,6 +60,11 @@ func (a *SignedAggregateAndProof) DecodeSSZ(buf []byte, version int) error {
 	return ssz2.UnmarshalSSZ(buf, version, a.Message, a.Signature[:])
 }
 
+func (a *SignedAggregateAndProof) DecodeSSZStrict(buf []byte, version int) error {
+	a.Message = new(AggregateAndProof)
+	return ssz2.UnmarshalSSZStrict(buf, version, a.Message, a.Signature[:])
+}
+
 func (a *SignedAggregateAndProof) EncodingSizeSSZ() int {
 	return 100 + a.Message.EncodingSizeSSZ()
 }
@@ -83,14 +96,15 @@ func (agg *SyncAggregate) Sum() int {
 }
 
 func (agg *SyncAggregate) IsSet(idx uint64) bool {
-	if idx >= 2048 {
+	if idx >= uint64(len(agg.SyncCommiteeBits))*8 {
 		return false
 	}
 	return agg.SyncCommiteeBits[idx/8]&(1<<(idx%8)) > 0
 }
 
 func (agg *SyncAggregate) EncodeSSZ(buf []byte) ([]byte, error) {
-	return append(buf, append(agg.SyncCommiteeBits[:], agg.SyncCommiteeSignature[:]...)...), nil
+	buf = append(buf, agg.SyncCommiteeBits[:]...)
+	return append(buf, agg.SyncCommiteeSignature[:]...), nil
 }
 
 func (*SyncAggregate) Static() bool {
@@ -98,7 +112,13 @@ func (*SyncAggregate) Static() bool {
 }
 
 func (agg *SyncAggregate) DecodeSSZ(buf []byte, version int) error {
-	return ssz2.UnmarshalSSZ(buf, version, agg.SyncCommiteeBits[:], agg.SyncCommiteeSignature[:])
+	if len(buf) < agg.EncodingSizeSSZ() {
+		return fmt.Errorf("[SyncAggregate] err: %w", ssz.ErrLowBufferSize)
+	}
+	if err := ssz2.UnmarshalSSZ(buf, version, agg.SyncCommiteeBits[:], agg.SyncCommiteeSignature[:]); err != nil {
+		return fmt.Errorf("[SyncAggregate] err: %w", err)
+	}
+	return nil
 }
 
 func (agg *SyncAggregate) EncodingSizeSSZ() int {
@@ -106,6 +126,10 @@ func (agg *SyncAggregate) EncodingSizeSSZ() int {
 }
 
 func (agg *SyncAggregate) HashSSZ() ([32]byte, error) {
-	return merkle_tree.HashTreeRoot(agg.SyncCommiteeBits[:], agg.SyncCommiteeSignature[:])
+	bitsRoot, err := merkle_tree.BitvectorRoot(agg.SyncCommiteeBits[:], uint64(len(agg.SyncCommiteeBits))*8)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return merkle_tree.HashTreeRoot(bitsRoot[:], agg.SyncCommiteeSignature[:])
 
 }
@@ -0,0 +1,76 @@
+package cltypes_test
+
+import (
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
+)
+
+func TestSyncAggregate(t *testing.T) {
+	agg := &cltypes.SyncAggregate{}
+	agg.SyncCommiteeBits[0] = 0xff
+	agg.SyncCommiteeBits[1] = 0x0f
+	agg.SyncCommiteeBits[63] = 0x80
+	for i := range agg.SyncCommiteeSignature {
+		agg.SyncCommiteeSignature[i] = byte(i)
+	}
+
+	root, err := agg.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, libcommon.HexToHash("0x15b361e92b15503e844043cce2290b66f5ef59ff7040b5f3c119069f5645995a"), libcommon.Hash(root))
+	root, err = (&cltypes.SyncAggregate{}).HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, libcommon.HexToHash("0x42b052541dce45557d83d34634a45a56d216d4375e5a9584f6445ce4e63324af"), libcommon.Hash(root))
+
+	// The bits are a fixed 512 bits bitvector, so they are encoded as is, without length bit.
+	encoded, err := agg.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Len(t, encoded, 160)
+	require.Equal(t, agg.SyncCommiteeBits[:], encoded[:64])
+	require.Equal(t, agg.SyncCommiteeSignature[:], encoded[64:])
+
+	decoded := &cltypes.SyncAggregate{}
+	require.NoError(t, decoded.DecodeSSZ(encoded, 0))
+	require.Equal(t, agg, decoded)
+	require.ErrorIs(t, decoded.DecodeSSZ(encoded[:159], 0), ssz.ErrLowBufferSize)
+
+	require.Equal(t, 13, agg.Sum())
+	require.True(t, agg.IsSet(0))
+	require.True(t, agg.IsSet(11))
+	require.False(t, agg.IsSet(12))
+	require.True(t, agg.IsSet(511))
+	require.False(t, agg.IsSet(512))
+	require.False(t, agg.IsSet(2047))
+}
+
+func TestSignedAggregateAndProofDecodeSSZStrict(t *testing.T) {
+	encode := func(aggregationBits []byte) []byte {
+		aggregate := &cltypes.SignedAggregateAndProof{
+			Message: &cltypes.AggregateAndProof{
+				AggregatorIndex: 1,
+				Aggregate:       solid.NewAttestionFromParameters(aggregationBits, solid.NewAttestationData(), [96]byte{2}),
+				SelectionProof:  [96]byte{3},
+			},
+			Signature: [96]byte{4},
+		}
+		encoded, err := aggregate.EncodeSSZ(nil)
+		require.NoError(t, err)
+		return encoded
+	}
+
+	canonical := encode([]byte{0x0f})
+	require.NoError(t, new(cltypes.SignedAggregateAndProof).DecodeSSZ(canonical, 0))
+	decoded := new(cltypes.SignedAggregateAndProof)
+	require.NoError(t, decoded.DecodeSSZStrict(canonical, 0))
+	require.Equal(t, []byte{0x0f}, decoded.Message.Aggregate.AggregationBits())
+
+	// The nested aggregation bits end with a zero byte: fine for the lenient decoder only.
+	nonCanonical := encode([]byte{0x0f, 0x00})
+	require.NoError(t, new(cltypes.SignedAggregateAndProof).DecodeSSZ(nonCanonical, 0))
+	require.ErrorIs(t, new(cltypes.SignedAggregateAndProof).DecodeSSZStrict(nonCanonical, 0), ssz.ErrNonCanonical)
+}
@@ -42,7 +42,7 @@ type BeaconBody struct {
 	// Data related to the Ethereum 1.0 chain
 	Eth1Data *Eth1Data `json:"eth1_data"`
 	// A byte array used to customize validators' behavior
-	Graffiti libcommon.Hash `json:"graffiti"`
+	Graffiti Graffiti `json:"graffiti"`
 	// A list of slashing events for validators who included invalid blocks in the chain
 	ProposerSlashings *solid.ListSSZ[*ProposerSlashing] `json:"proposer_slashings"`
 	// A list of slashing events for validators who included invalid attestations in the chain
@@ -119,15 +119,21 @@ func (b *BeaconBlock) Blinded() (*BlindedBeaconBlock, error) {
 	}, nil
 }
 
+// NewAttestationList returns an empty SSZ list of attestations, as carried by a block body. Attestations
+// are variable size because of their aggregation bits, so the list is encoded with an offset table.
+func NewAttestationList() *solid.ListSSZ[*solid.Attestation] {
+	return solid.NewDynamicListSSZ[*solid.Attestation](MaxAttestations)
+}
+
 func NewBeaconBody(beaconCfg *clparams.BeaconChainConfig) *BeaconBody {
 	return &BeaconBody{
 		beaconCfg:          beaconCfg,
 		Eth1Data:           &Eth1Data{},
-		ProposerSlashings:  solid.NewStaticListSSZ[*ProposerSlashing](MaxProposerSlashings, 416),
-		AttesterSlashings:  solid.NewDynamicListSSZ[*AttesterSlashing](MaxAttesterSlashings),
-		Attestations:       solid.NewDynamicListSSZ[*solid.Attestation](MaxAttestations),
-		Deposits:           solid.NewStaticListSSZ[*Deposit](MaxDeposits, 1240),
-		VoluntaryExits:     solid.NewStaticListSSZ[*SignedVoluntaryExit](MaxVoluntaryExits, 112),
+		ProposerSlashings:  NewProposerSlashingList(),
+		AttesterSlashings:  NewAttesterSlashingList(),
+		Attestations:       NewAttestationList(),
+		Deposits:           NewDepositList(),
+		VoluntaryExits:     NewSignedVoluntaryExitList(),
 		ExecutionPayload:   NewEth1Block(clparams.Phase0Version, beaconCfg),
 		ExecutionChanges:   solid.NewStaticListSSZ[*SignedBLSToExecutionChange](MaxExecutionChanges, 172),
 		BlobKzgCommitments: solid.NewStaticListSSZ[*KZGCommitment](MaxBlobsCommittmentsPerBlock, 48),
@@ -158,19 +164,19 @@ func (b *BeaconBody) EncodingSizeSSZ() (size int) {
 	}
 
 	if b.ProposerSlashings == nil {
-		b.ProposerSlashings = solid.NewStaticListSSZ[*ProposerSlashing](MaxProposerSlashings, 416)
+		b.ProposerSlashings = NewProposerSlashingList()
 	}
 	if b.AttesterSlashings == nil {
-		b.AttesterSlashings = solid.NewDynamicListSSZ[*AttesterSlashing](MaxAttesterSlashings)
+		b.AttesterSlashings = NewAttesterSlashingList()
 	}
 	if b.Attestations == nil {
-		b.Attestations = solid.NewDynamicListSSZ[*solid.Attestation](MaxAttestations)
+		b.Attestations = NewAttestationList()
 	}
 	if b.Deposits == nil {
-		b.Deposits = solid.NewStaticListSSZ[*Deposit](MaxDeposits, 1240)
+		b.Deposits = NewDepositList()
 	}
 	if b.VoluntaryExits == nil {
-		b.VoluntaryExits = solid.NewStaticListSSZ[*SignedVoluntaryExit](MaxVoluntaryExits, 112)
+		b.VoluntaryExits = NewSignedVoluntaryExitList()
 	}
 	if b.ExecutionPayload == nil {
 		b.ExecutionPayload = NewEth1Block(b.Version, b.beaconCfg)
@@ -182,19 +188,25 @@ func (b *BeaconBody) EncodingSizeSSZ() (size int) {
 		b.BlobKzgCommitments = solid.NewStaticListSSZ[*KZGCommitment](MaxBlobsCommittmentsPerBlock, 48)
 	}
 
+	// Fixed part: randao reveal, eth1 data, graffiti and the offsets of the five operation lists.
+	size = len(b.RandaoReveal) + b.Eth1Data.EncodingSizeSSZ() + len(b.Graffiti) + 5*4
 	size += b.ProposerSlashings.EncodingSizeSSZ()
 	size += b.AttesterSlashings.EncodingSizeSSZ()
 	size += b.Attestations.EncodingSizeSSZ()
 	size += b.Deposits.EncodingSizeSSZ()
 	size += b.VoluntaryExits.EncodingSizeSSZ()
+	if b.Version >= clparams.AltairVersion {
+		size += b.SyncAggregate.EncodingSizeSSZ()
+	}
+	// Each of the following fields is variable size, so it also takes an offset.
 	if b.Version >= clparams.BellatrixVersion {
-		size += b.ExecutionPayload.EncodingSizeSSZ()
+		size += 4 + b.ExecutionPayload.EncodingSizeSSZ()
 	}
 	if b.Version >= clparams.CapellaVersion {
-		size += b.ExecutionChanges.EncodingSizeSSZ()
+		size += 4 + b.ExecutionChanges.EncodingSizeSSZ()
 	}
 	if b.Version >= clparams.DenebVersion {
-		size += b.ExecutionChanges.EncodingSizeSSZ()
+		size += 4 + b.BlobKzgCommitments.EncodingSizeSSZ()
 	}
 
 	return
@@ -261,10 +273,11 @@ func (b *BeaconBlock) EncodeSSZ(buf []byte) (dst []byte, err error) {
 }
 
 func (b *BeaconBlock) EncodingSizeSSZ() int {
+	// Slot, proposer index, parent root, state root and the offset of the body.
 	if b.Body == nil {
-		return 80
+		return 84
 	}
-	return 80 + b.Body.EncodingSizeSSZ()
+	return 84 + b.Body.EncodingSizeSSZ()
 }
 
 func (b *BeaconBlock) DecodeSSZ(buf []byte, version int) error {
@@ -290,10 +303,27 @@ func (b *SignedBeaconBlock) DecodeSSZ(buf []byte, s int) error {
 	return ssz2.UnmarshalSSZ(buf, s, b.Block, b.Signature[:])
 }
 
+// HashSSZ returns the root of the signed container, signature included. Blocks are identified by their
+// BlockRoot instead.
 func (b *SignedBeaconBlock) HashSSZ() ([32]byte, error) {
 	return merkle_tree.HashTreeRoot(b.Block, b.Signature[:])
 }
 
+// BlockRoot returns the root of the block message, which identifies the block: it does not depend on the
+// signature and equals the root of the block header.
+func (b *SignedBeaconBlock) BlockRoot() ([32]byte, error) {
+	return b.Block.HashSSZ()
+}
+
+// SigningRoot returns the root the block signature is made over for the given domain.
+func (b *SignedBeaconBlock) SigningRoot(domain [32]byte) ([32]byte, error) {
+	root, err := b.BlockRoot()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return merkle_tree.SigningRoot(root, domain), nil
+}
+
 func (*BeaconBody) Static() bool {
 	return false
 }
@@ -322,7 +352,7 @@ func (b *BeaconBody) UnmarshalJSON(buf []byte) error {
 	var tmp struct {
 		RandaoReveal       libcommon.Bytes96                           `json:"randao_reveal"`
 		Eth1Data           *Eth1Data                                   `json:"eth1_data"`
-		Graffiti           libcommon.Hash                              `json:"graffiti"`
+		Graffiti           Graffiti                                    `json:"graffiti"`
 		ProposerSlashings  *solid.ListSSZ[*ProposerSlashing]           `json:"proposer_slashings"`
 		AttesterSlashings  *solid.ListSSZ[*AttesterSlashing]           `json:"attester_slashings"`
 		Attestations       *solid.ListSSZ[*solid.Attestation]          `json:"attestations"`
@@ -333,11 +363,11 @@ func (b *BeaconBody) UnmarshalJSON(buf []byte) error {
 		ExecutionChanges   *solid.ListSSZ[*SignedBLSToExecutionChange] `json:"bls_to_execution_changes,omitempty"`
 		BlobKzgCommitments *solid.ListSSZ[*KZGCommitment]              `json:"blob_kzg_commitments,omitempty"`
 	}
-	tmp.ProposerSlashings = solid.NewStaticListSSZ[*ProposerSlashing](MaxProposerSlashings, 416)
-	tmp.AttesterSlashings = solid.NewDynamicListSSZ[*AttesterSlashing](MaxAttesterSlashings)
-	tmp.Attestations = solid.NewDynamicListSSZ[*solid.Attestation](MaxAttestations)
-	tmp.Deposits = solid.NewStaticListSSZ[*Deposit](MaxDeposits, 1240)
-	tmp.VoluntaryExits = solid.NewStaticListSSZ[*SignedVoluntaryExit](MaxVoluntaryExits, 112)
+	tmp.ProposerSlashings = NewProposerSlashingList()
+	tmp.AttesterSlashings = NewAttesterSlashingList()
+	tmp.Attestations = NewAttestationList()
+	tmp.Deposits = NewDepositList()
+	tmp.VoluntaryExits = NewSignedVoluntaryExitList()
 	tmp.ExecutionChanges = solid.NewStaticListSSZ[*SignedBLSToExecutionChange](MaxExecutionChanges, 172)
 	tmp.BlobKzgCommitments = solid.NewStaticListSSZ[*KZGCommitment](MaxBlobsCommittmentsPerBlock, 48)
 	tmp.ExecutionPayload = NewEth1Block(b.Version, b.beaconCfg)
@@ -1,6 +1,8 @@
 package cltypes
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"math/big"
 	"testing"
@@ -27,10 +29,10 @@ func TestBeaconBody(t *testing.T) {
 	randaoReveal := [96]byte{1, 2, 3}
 	eth1Data := &Eth1Data{}
 	graffiti := [32]byte{4, 5, 6}
-	proposerSlashings := solid.NewStaticListSSZ[*ProposerSlashing](MaxProposerSlashings, 416)
-	attesterSlashings := solid.NewDynamicListSSZ[*AttesterSlashing](MaxAttesterSlashings)
+	proposerSlashings := NewProposerSlashingList()
+	attesterSlashings := NewAttesterSlashingList()
 	attestations := solid.NewDynamicListSSZ[*solid.Attestation](MaxAttestations)
-	deposits := solid.NewStaticListSSZ[*Deposit](MaxDeposits, 1240)
+	deposits := NewDepositList()
 	voluntaryExits := solid.NewStaticListSSZ[*SignedVoluntaryExit](MaxVoluntaryExits, 112)
 	syncAggregate := &SyncAggregate{}
 	executionChanges := solid.NewStaticListSSZ[*SignedBLSToExecutionChange](MaxExecutionChanges, 172)
@@ -121,3 +123,169 @@ func TestBeaconBlockJson(t *testing.T) {
 	assert.Equal(t, map1, map2)
 	assert.Equal(t, libcommon.Hash(r), libcommon.HexToHash("0x1a9b89eb12282543a5fa0b0f251d8ec0c5c432121d7cb2a8d78461ea9d10c294"))
 }
+
+func TestPhase0BeaconBody(t *testing.T) {
+	body := NewBeaconBody(&clparams.MainnetBeaconConfig)
+	body.RandaoReveal[0] = 1
+	body.Eth1Data = &Eth1Data{Root: libcommon.Hash{2}, DepositCount: 3, BlockHash: libcommon.Hash{4}}
+	body.Graffiti[0] = 5
+
+	root, err := body.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, libcommon.HexToHash("0xed7400f0205627d19ae1f4b784d50a164ebbf7e4fa9b6c845a7b1ad14591095e"), libcommon.Hash(root))
+	encoded, err := body.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Len(t, encoded, 220)
+	require.Len(t, encoded, body.EncodingSizeSSZ())
+
+	header := func(bodyRoot byte) *SignedBeaconBlockHeader {
+		return &SignedBeaconBlockHeader{
+			Header:    &BeaconBlockHeader{Slot: 1, ProposerIndex: 2, ParentRoot: libcommon.Hash{3}, Root: libcommon.Hash{4}, BodyRoot: libcommon.Hash{bodyRoot}},
+			Signature: libcommon.Bytes96{6},
+		}
+	}
+	data := func(blockRoot byte) solid.AttestationData {
+		return solid.NewAttestionDataFromParameters(10, 0, libcommon.Hash{blockRoot},
+			solid.NewCheckpointFromParameters(libcommon.Hash{12}, 1), solid.NewCheckpointFromParameters(libcommon.Hash{13}, 2))
+	}
+	indexed := func(indices []uint64, blockRoot, signature byte) *IndexedAttestation {
+		return &IndexedAttestation{AttestingIndices: solid.NewRawUint64List(2048, indices), Data: data(blockRoot), Signature: libcommon.Bytes96{signature}}
+	}
+	proof := solid.NewHashVector(DepositProofLength)
+	for i := 0; i < DepositProofLength; i++ {
+		proof.Set(i, libcommon.Hash{byte(i)})
+	}
+
+	body.ProposerSlashings.Append(&ProposerSlashing{Header1: header(5), Header2: header(7)})
+	body.AttesterSlashings.Append(&AttesterSlashing{Attestation_1: indexed([]uint64{1, 2, 3}, 11, 8), Attestation_2: indexed([]uint64{1, 2}, 14, 9)})
+	// Three aggregation bits, the first two set, followed by the length bit.
+	body.Attestations.Append(solid.NewAttestionFromParameters([]byte{0x0b}, data(11), [96]byte{15}))
+	body.Deposits.Append(&Deposit{Proof: proof, Data: &DepositData{PubKey: [48]byte{16}, WithdrawalCredentials: libcommon.Hash{17}, Amount: 32000000000, Signature: [96]byte{18}}})
+	body.VoluntaryExits.Append(&SignedVoluntaryExit{VoluntaryExit: &VoluntaryExit{Epoch: 19, ValidatorIndex: 20}, Signature: [96]byte{21}})
+
+	root, err = body.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, libcommon.HexToHash("0xe6a1d79de472edea6d3b76efc73dd1414987f96ba0075d0a0309e99f67db30c4"), libcommon.Hash(root))
+	encoded, err = body.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Len(t, encoded, body.EncodingSizeSSZ())
+
+	decoded := NewBeaconBody(&clparams.MainnetBeaconConfig)
+	require.NoError(t, decoded.DecodeSSZ(encoded, int(clparams.Phase0Version)))
+	require.Equal(t, 1, decoded.Deposits.Len())
+	require.True(t, body.Deposits.Get(0).Equal(decoded.Deposits.Get(0)))
+	require.True(t, body.VoluntaryExits.Get(0).Equal(decoded.VoluntaryExits.Get(0)))
+	decodedRoot, err := decoded.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, root, decodedRoot)
+	reencoded, err := decoded.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Equal(t, encoded, reencoded)
+}
+
+func TestBeaconBlockEncodingSizeSSZ(t *testing.T) {
+	_, _, bc := clparams.GetConfigsByNetwork(clparams.GnosisNetwork)
+	block := NewSignedBeaconBlock(bc)
+	require.NoError(t, block.DecodeSSZ(beaconBodySSZ, int(clparams.DenebVersion)))
+	require.Equal(t, len(beaconBodySSZ), block.EncodingSizeSSZ())
+
+	blinded, err := block.Blinded()
+	require.NoError(t, err)
+	encoded, err := blinded.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Len(t, encoded, blinded.EncodingSizeSSZ())
+}
+
+func TestSignedBeaconBlockRoots(t *testing.T) {
+	_, _, bc := clparams.GetConfigsByNetwork(clparams.GnosisNetwork)
+	block := NewSignedBeaconBlock(bc)
+	require.NoError(t, block.DecodeSSZ(beaconBodySSZ, int(clparams.DenebVersion)))
+	encoded, err := block.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Equal(t, beaconBodySSZ, encoded)
+
+	blockRoot, err := block.BlockRoot()
+	require.NoError(t, err)
+	require.Equal(t, libcommon.HexToHash("0x1a9b89eb12282543a5fa0b0f251d8ec0c5c432121d7cb2a8d78461ea9d10c294"), libcommon.Hash(blockRoot))
+	headerRoot, err := block.SignedBeaconBlockHeader().Header.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, headerRoot, blockRoot)
+	signedRoot, err := block.HashSSZ()
+	require.NoError(t, err)
+	require.NotEqual(t, blockRoot, signedRoot)
+
+	domain := [32]byte{1}
+	signingRoot, err := block.SigningRoot(domain)
+	require.NoError(t, err)
+	headerSigningRoot, err := block.SignedBeaconBlockHeader().SigningRoot(domain)
+	require.NoError(t, err)
+	require.Equal(t, headerSigningRoot, signingRoot)
+
+	// The signature changes the root of the signed block only.
+	block.Signature[0] ^= 1
+	changedBlockRoot, err := block.BlockRoot()
+	require.NoError(t, err)
+	require.Equal(t, blockRoot, changedBlockRoot)
+	changedSignedRoot, err := block.HashSSZ()
+	require.NoError(t, err)
+	require.NotEqual(t, signedRoot, changedSignedRoot)
+
+	decoded := NewSignedBeaconBlock(bc)
+	encoded, err = block.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.NoError(t, decoded.DecodeSSZ(encoded, int(clparams.DenebVersion)))
+	require.Equal(t, block.Signature, decoded.Signature)
+	decodedRoot, err := decoded.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, changedSignedRoot, decodedRoot)
+}
+
+func TestAttestationList(t *testing.T) {
+	empty, err := NewAttestationList().HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, libcommon.HexToHash("0x96559674a79656e540871e1f39c9b91e152aa8cddb71493e754827c4cc809d57"), libcommon.Hash(empty))
+
+	// Aggregation bits of 0, 3 and 257 bits.
+	aggregationBits := [][]byte{{0x01}, {0x0b}, append(bytes.Repeat([]byte{0xff}, 32), 0x03)}
+	expectedRoots := []string{
+		"0x5d438aa410c73fce1dae808b866e30b4be2fc21dee12fba58362593f90bcb10c",
+		"0xe77ffdd3ea5bdead8ec8a3df5bb41ad4e34cb6497b12d24d6120b992a8fd6e28",
+		"0x280fef7f157a4d33af3090feda4054d72f5d5ad5d95e25e000559ff167453e46",
+	}
+	attestations := NewAttestationList()
+	for i, bits := range aggregationBits {
+		data := solid.NewAttestionDataFromParameters(uint64(100+i), uint64(i), libcommon.Hash(bytes.Repeat([]byte{byte(i + 1)}, 32)),
+			solid.NewCheckpointFromParameters(libcommon.Hash(bytes.Repeat([]byte{0xaa}, 32)), uint64(i)),
+			solid.NewCheckpointFromParameters(libcommon.Hash(bytes.Repeat([]byte{0xbb}, 32)), uint64(i+1)))
+		attestation := solid.NewAttestionFromParameters(bits, data, [96]byte{byte(i)})
+		root, err := attestation.HashSSZ()
+		require.NoError(t, err)
+		require.Equal(t, libcommon.HexToHash(expectedRoots[i]), libcommon.Hash(root))
+		attestations.Append(attestation)
+	}
+	root, err := attestations.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, libcommon.HexToHash("0x8047b7436e214e3d8bc5d9481d698744ad876a5f298fd17ed42209cc0af01da4"), libcommon.Hash(root))
+
+	// The offset table points past itself to each element.
+	encoded, err := attestations.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Len(t, encoded, 3*4+229+229+261)
+	require.Equal(t, uint32(12), binary.LittleEndian.Uint32(encoded[0:]))
+	require.Equal(t, uint32(12+229), binary.LittleEndian.Uint32(encoded[4:]))
+	require.Equal(t, uint32(12+229+229), binary.LittleEndian.Uint32(encoded[8:]))
+
+	decoded := NewAttestationList()
+	require.NoError(t, decoded.DecodeSSZ(encoded, 0))
+	require.Equal(t, 3, decoded.Len())
+	for i, bits := range aggregationBits {
+		require.Equal(t, bits, decoded.Get(i).AggregationBits())
+	}
+	decodedRoot, err := decoded.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, root, decodedRoot)
+
+	// An offset pointing inside the offset table.
+	binary.LittleEndian.PutUint32(encoded[4:], 8)
+	require.Error(t, NewAttestationList().DecodeSSZ(encoded, 0))
+}
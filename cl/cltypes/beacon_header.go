@@ -24,6 +24,15 @@ func (b *BeaconBlockHeader) Copy() *BeaconBlockHeader {
 	copied := *b
 	return &copied
 }
+
+// Equal returns whether two headers have the same content, as used by proposer slashing detection.
+func (b *BeaconBlockHeader) Equal(other *BeaconBlockHeader) bool {
+	if b == nil || other == nil {
+		return b == other
+	}
+	return *b == *other
+}
+
 func (b *BeaconBlockHeader) EncodeSSZ(dst []byte) ([]byte, error) {
 	return ssz2.MarshalSSZ(dst, b.Slot, b.ProposerIndex, b.ParentRoot[:], b.Root[:], b.BodyRoot[:])
 }
@@ -74,3 +83,12 @@ func (b *SignedBeaconBlockHeader) HashSSZ() ([32]byte, error) {
 func (b *SignedBeaconBlockHeader) EncodingSizeSSZ() int {
 	return b.Header.EncodingSizeSSZ() + 96
 }
+
+// SigningRoot returns the root the header signature is made over for the given domain.
+func (b *SignedBeaconBlockHeader) SigningRoot(domain [32]byte) ([32]byte, error) {
+	root, err := b.Header.HashSSZ()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return merkle_tree.SigningRoot(root, domain), nil
+}
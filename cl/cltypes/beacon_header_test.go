@@ -0,0 +1,45 @@
+package cltypes_test
+
+import (
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+)
+
+var testBeaconBlockHeader = &cltypes.BeaconBlockHeader{
+	Slot:          3141592,
+	ProposerIndex: 271828,
+	ParentRoot:    libcommon.HexToHash("0x1111111111111111111111111111111111111111111111111111111111111111"),
+	Root:          libcommon.HexToHash("0x2222222222222222222222222222222222222222222222222222222222222222"),
+	BodyRoot:      libcommon.HexToHash("0x3333333333333333333333333333333333333333333333333333333333333333"),
+}
+
+func TestBeaconBlockHeaderHashSSZ(t *testing.T) {
+	root, err := testBeaconBlockHeader.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, libcommon.HexToHash("0x333aaf0617dbf5758cee29bd664c06054d9c4865b162cae3be82f2977b1731b7"), libcommon.Hash(root))
+
+	encoded, err := testBeaconBlockHeader.EncodeSSZ(nil)
+	require.NoError(t, err)
+	decoded := &cltypes.BeaconBlockHeader{}
+	require.NoError(t, decoded.DecodeSSZ(encoded, 0))
+	require.True(t, decoded.Equal(testBeaconBlockHeader))
+}
+
+func TestSignedBeaconBlockHeaderSigningRoot(t *testing.T) {
+	signedHeader := &cltypes.SignedBeaconBlockHeader{Header: testBeaconBlockHeader, Signature: libcommon.Bytes96{1}}
+	signingRoot, err := signedHeader.SigningRoot([32]byte{1})
+	require.NoError(t, err)
+	require.Equal(t, libcommon.HexToHash("0x983e9c00c946b2be1b56b9cf72240de822a531af3dbb5e00116272af12767e02"), libcommon.Hash(signingRoot))
+}
+
+func TestBeaconBlockHeaderEqual(t *testing.T) {
+	other := testBeaconBlockHeader.Copy()
+	require.True(t, testBeaconBlockHeader.Equal(other))
+	other.BodyRoot[0] ^= 1
+	require.False(t, testBeaconBlockHeader.Equal(other))
+	require.False(t, testBeaconBlockHeader.Equal(nil))
+}
@@ -32,7 +32,7 @@ type BlindedBeaconBody struct {
 	// Data related to the Ethereum 1.0 chain
 	Eth1Data *Eth1Data `json:"eth1_data"`
 	// A byte array used to customize validators' behavior
-	Graffiti libcommon.Hash `json:"graffiti"`
+	Graffiti Graffiti `json:"graffiti"`
 	// A list of slashing events for validators who included invalid blocks in the chain
 	ProposerSlashings *solid.ListSSZ[*ProposerSlashing] `json:"proposer_slashings"`
 	// A list of slashing events for validators who included invalid attestations in the chain
@@ -133,19 +133,19 @@ func (b *BlindedBeaconBody) EncodingSizeSSZ() (size int) {
 		b.ExecutionPayload = NewEth1Header(b.Version)
 	}
 	if b.ProposerSlashings == nil {
-		b.ProposerSlashings = solid.NewStaticListSSZ[*ProposerSlashing](MaxProposerSlashings, 416)
+		b.ProposerSlashings = NewProposerSlashingList()
 	}
 	if b.AttesterSlashings == nil {
-		b.AttesterSlashings = solid.NewDynamicListSSZ[*AttesterSlashing](MaxAttesterSlashings)
+		b.AttesterSlashings = NewAttesterSlashingList()
 	}
 	if b.Attestations == nil {
-		b.Attestations = solid.NewDynamicListSSZ[*solid.Attestation](MaxAttestations)
+		b.Attestations = NewAttestationList()
 	}
 	if b.Deposits == nil {
-		b.Deposits = solid.NewStaticListSSZ[*Deposit](MaxDeposits, 1240)
+		b.Deposits = NewDepositList()
 	}
 	if b.VoluntaryExits == nil {
-		b.VoluntaryExits = solid.NewStaticListSSZ[*SignedVoluntaryExit](MaxVoluntaryExits, 112)
+		b.VoluntaryExits = NewSignedVoluntaryExitList()
 	}
 	if b.ExecutionPayload == nil {
 		b.ExecutionPayload = NewEth1Header(b.Version)
@@ -157,19 +157,25 @@ func (b *BlindedBeaconBody) EncodingSizeSSZ() (size int) {
 		b.BlobKzgCommitments = solid.NewStaticListSSZ[*KZGCommitment](MaxBlobsCommittmentsPerBlock, 48)
 	}
 
+	// Fixed part: randao reveal, eth1 data, graffiti and the offsets of the five operation lists.
+	size = len(b.RandaoReveal) + b.Eth1Data.EncodingSizeSSZ() + len(b.Graffiti) + 5*4
 	size += b.ProposerSlashings.EncodingSizeSSZ()
 	size += b.AttesterSlashings.EncodingSizeSSZ()
 	size += b.Attestations.EncodingSizeSSZ()
 	size += b.Deposits.EncodingSizeSSZ()
 	size += b.VoluntaryExits.EncodingSizeSSZ()
+	if b.Version >= clparams.AltairVersion {
+		size += b.SyncAggregate.EncodingSizeSSZ()
+	}
+	// Each of the following fields is variable size, so it also takes an offset.
 	if b.Version >= clparams.BellatrixVersion {
-		size += b.ExecutionPayload.EncodingSizeSSZ()
+		size += 4 + b.ExecutionPayload.EncodingSizeSSZ()
 	}
 	if b.Version >= clparams.CapellaVersion {
-		size += b.ExecutionChanges.EncodingSizeSSZ()
+		size += 4 + b.ExecutionChanges.EncodingSizeSSZ()
 	}
 	if b.Version >= clparams.DenebVersion {
-		size += b.ExecutionChanges.EncodingSizeSSZ()
+		size += 4 + b.BlobKzgCommitments.EncodingSizeSSZ()
 	}
 
 	return
@@ -256,10 +262,11 @@ func (b *BlindedBeaconBlock) EncodeSSZ(buf []byte) (dst []byte, err error) {
 }
 
 func (b *BlindedBeaconBlock) EncodingSizeSSZ() int {
+	// Slot, proposer index, parent root, state root and the offset of the body.
 	if b.Body == nil {
-		return 80
+		return 84
 	}
-	return 80 + b.Body.EncodingSizeSSZ()
+	return 84 + b.Body.EncodingSizeSSZ()
 }
 
 func (b *BlindedBeaconBlock) DecodeSSZ(buf []byte, version int) error {
@@ -36,6 +36,10 @@ func (*HistoricalSummary) Clone() clonable.Clonable {
 	return &HistoricalSummary{}
 }
 
+func (*DepositMessage) Clone() clonable.Clonable {
+	return &DepositMessage{}
+}
+
 func (*DepositData) Clone() clonable.Clonable {
 	return &DepositData{}
 }
@@ -102,6 +106,10 @@ func (*Fork) Clone() clonable.Clonable {
 	return &Fork{}
 }
 
+func (*ForkData) Clone() clonable.Clonable {
+	return &ForkData{}
+}
+
 func (*KZGCommitment) Clone() clonable.Clonable {
 	return &KZGCommitment{}
 }
@@ -105,8 +105,10 @@ func (a *Contribution) DecodeSSZ(buf []byte, version int) error {
 	return ssz2.UnmarshalSSZ(buf, version, &a.Slot, a.BeaconBlockRoot[:], &a.SubcommitteeIndex, []byte(a.AggregationBits), a.Signature[:])
 }
 
+// EncodingSizeSSZ is fixed: decoders size the buffer of an empty contribution with it, before the aggregation
+// bits are allocated.
 func (a *Contribution) EncodingSizeSSZ() int {
-	return length.BlockNum*2 + length.Hash + length.Bytes96 + len(a.AggregationBits)
+	return length.BlockNum*2 + length.Hash + length.Bytes96 + SyncCommitteeAggregationBitsSize
 }
 
 func (a *Contribution) HashSSZ() ([32]byte, error) {
@@ -143,7 +145,8 @@ func (agg *SyncContribution) IsSet(idx uint64) bool {
 }
 
 func (agg *SyncContribution) EncodeSSZ(buf []byte) ([]byte, error) {
-	return append(buf, append(agg.SyncCommiteeBits[:], agg.SyncCommiteeSignature[:]...)...), nil
+	buf = append(buf, agg.SyncCommiteeBits[:]...)
+	return append(buf, agg.SyncCommiteeSignature[:]...), nil
 }
 
 func (*SyncContribution) Static() bool {
@@ -0,0 +1,37 @@
+package cltypes_test
+
+import (
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+)
+
+func TestSignedContributionAndProofDecodeSSZ(t *testing.T) {
+	signed := &cltypes.SignedContributionAndProof{
+		Message: &cltypes.ContributionAndProof{
+			AggregatorIndex: 1,
+			Contribution: &cltypes.Contribution{
+				Slot:              2,
+				BeaconBlockRoot:   libcommon.Hash{3},
+				SubcommitteeIndex: 4,
+				AggregationBits:   make([]byte, cltypes.SyncCommitteeAggregationBitsSize),
+				Signature:         libcommon.Bytes96{5},
+			},
+			SelectionProof: libcommon.Bytes96{6},
+		},
+		Signature: libcommon.Bytes96{7},
+	}
+	signed.Message.Contribution.AggregationBits[0] = 0xff
+	encoded, err := signed.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Len(t, encoded, 360)
+
+	decoded := &cltypes.SignedContributionAndProof{}
+	require.NoError(t, decoded.DecodeSSZ(encoded, 0))
+	require.Equal(t, signed, decoded)
+	// An empty contribution is sized before its aggregation bits are allocated.
+	require.Equal(t, 160, new(cltypes.Contribution).EncodingSizeSSZ())
+}
@@ -0,0 +1,67 @@
+package cltypes_test
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
+)
+
+// TestEncodeSSZAppendOnly checks that EncodeSSZ only appends to the given buffer and that the returned
+// buffer does not share memory with the encoded object: scribbling over it must leave the object intact.
+func TestEncodeSSZAppendOnly(t *testing.T) {
+	proof := solid.NewHashVector(cltypes.DepositProofLength)
+	for i := 0; i < cltypes.DepositProofLength; i++ {
+		proof.Set(i, libcommon.Hash{byte(i + 1)})
+	}
+	depositData := &cltypes.DepositData{PubKey: [48]byte{1}, WithdrawalCredentials: [32]byte{2}, Amount: 3, Signature: [96]byte{4}}
+	committee := make([]libcommon.Bytes48, cltypes.SyncCommitteeSize)
+	for i := range committee {
+		committee[i] = libcommon.Bytes48{byte(i), 1}
+	}
+	syncCommittee := solid.NewSyncCommitteeFromParameters(committee, libcommon.Bytes48{9})
+	header := &cltypes.BeaconBlockHeader{Slot: 1, ProposerIndex: 2, ParentRoot: libcommon.Hash{3}, Root: libcommon.Hash{4}, BodyRoot: libcommon.Hash{5}}
+
+	tests := []ssz.Marshaler{
+		depositData,
+		&cltypes.Deposit{Proof: proof, Data: depositData},
+		&cltypes.SignedVoluntaryExit{VoluntaryExit: &cltypes.VoluntaryExit{Epoch: 1, ValidatorIndex: 2}, Signature: [96]byte{3}},
+		&cltypes.SyncAggregate{SyncCommiteeBits: libcommon.Bytes64{0xff}, SyncCommiteeSignature: libcommon.Bytes96{1}},
+		&cltypes.SignedBeaconBlockHeader{Header: header, Signature: [96]byte{6}},
+		&cltypes.Eth1Data{Root: libcommon.Hash{1}, DepositCount: 2, BlockHash: libcommon.Hash{3}},
+		syncCommittee,
+		solid.NewSyncCommitteePair(syncCommittee, syncCommittee.Copy()),
+		solid.NewValidatorFromParameters([48]byte{1}, [32]byte{2}, 3, true, 4, 5, 6, 7),
+		solid.NewCheckpointFromParameters(libcommon.Hash{1}, 2),
+		proof,
+	}
+	for _, obj := range tests {
+		t.Run(fmt.Sprintf("%T", obj), func(t *testing.T) {
+			expected, err := obj.EncodeSSZ(nil)
+			require.NoError(t, err)
+			expected = bytes.Clone(expected)
+
+			// Encoding after a prefix, in a buffer with room to spare, leaves the prefix alone.
+			prefix := []byte{0xde, 0xad}
+			dst := make([]byte, len(prefix), len(prefix)+obj.EncodingSizeSSZ()+16)
+			copy(dst, prefix)
+			encoded, err := obj.EncodeSSZ(dst)
+			require.NoError(t, err)
+			require.Equal(t, prefix, encoded[:len(prefix)])
+			require.Equal(t, expected, encoded[len(prefix):])
+
+			for i := range encoded {
+				encoded[i] ^= 0xff
+			}
+			reencoded, err := obj.EncodeSSZ(nil)
+			require.NoError(t, err)
+			require.Equal(t, expected, reencoded)
+		})
+	}
+}
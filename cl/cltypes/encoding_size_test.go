@@ -0,0 +1,83 @@
+package cltypes_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/common/length"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
+)
+
+// fieldsSize returns the SSZ size of a fixed size type computed from its Go layout: uint64 fields are 8
+// bytes, byte arrays their length, and pointers to fixed size containers the sum of their fields.
+// Fields that are not plain data (e.g. solid vectors behind an interface) are looked up in overrides.
+func fieldsSize(t *testing.T, typ reflect.Type, overrides map[string]int) int {
+	switch typ.Kind() {
+	case reflect.Uint64:
+		return 8
+	case reflect.Uint8:
+		return 1
+	case reflect.Array:
+		return typ.Len() * fieldsSize(t, typ.Elem(), overrides)
+	case reflect.Pointer:
+		return fieldsSize(t, typ.Elem(), overrides)
+	case reflect.Struct:
+		size := 0
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if fieldSize, ok := overrides[typ.Name()+"."+field.Name]; ok {
+				size += fieldSize
+				continue
+			}
+			size += fieldsSize(t, field.Type, overrides)
+		}
+		return size
+	}
+	t.Fatalf("no fixed SSZ size for %s", typ)
+	return 0
+}
+
+func TestEncodingSizeSSZMatchesLayout(t *testing.T) {
+	overrides := map[string]int{
+		"Deposit.Proof":                cltypes.DepositProofLength * length.Hash,
+		"Contribution.AggregationBits": cltypes.SyncCommitteeAggregationBitsSize,
+	}
+	signedHeader := &cltypes.SignedBeaconBlockHeader{Header: &cltypes.BeaconBlockHeader{}}
+	tests := []interface{ EncodingSizeSSZ() int }{
+		&cltypes.DepositMessage{},
+		&cltypes.DepositData{},
+		&cltypes.Deposit{},
+		&cltypes.VoluntaryExit{},
+		&cltypes.SignedVoluntaryExit{},
+		&cltypes.SyncAggregate{},
+		&cltypes.Eth1Data{},
+		&cltypes.Fork{},
+		&cltypes.ForkData{},
+		&cltypes.HistoricalSummary{},
+		&cltypes.BeaconBlockHeader{},
+		signedHeader,
+		&cltypes.ProposerSlashing{Header1: signedHeader, Header2: signedHeader},
+		&cltypes.BLSToExecutionChange{},
+		&cltypes.SignedBLSToExecutionChange{},
+		&cltypes.SyncAggregatorSelectionData{},
+		// Empty, as decoders see them before decoding.
+		&cltypes.Contribution{},
+		&cltypes.ContributionAndProof{},
+		&cltypes.SignedContributionAndProof{Message: &cltypes.ContributionAndProof{}},
+	}
+	for _, obj := range tests {
+		typ := reflect.TypeOf(obj)
+		t.Run(typ.Elem().Name(), func(t *testing.T) {
+			require.Equal(t, fieldsSize(t, typ, overrides), obj.EncodingSizeSSZ())
+		})
+	}
+
+	// The sync committee is stored flat: 512 public keys followed by the aggregate public key.
+	require.Equal(t, (cltypes.SyncCommitteeSize+1)*length.Bytes48, (&solid.SyncCommittee{}).EncodingSizeSSZ())
+	require.Equal(t, 1240, (&cltypes.Deposit{}).EncodingSizeSSZ())
+	require.Equal(t, 184, (&cltypes.DepositData{}).EncodingSizeSSZ())
+	require.Equal(t, 24624, (&solid.SyncCommittee{}).EncodingSizeSSZ())
+}
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -32,3 +33,9 @@ func TestEth1DataHashTreeRoot(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, root[:], expectedTestEth1DataRoot)
 }
+
+func TestEth1DataDecodeShortBuffer(t *testing.T) {
+	marshalled, err := testEth1Data.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.ErrorIs(t, (&cltypes.Eth1Data{}).DecodeSSZ(marshalled[:len(marshalled)-1], 0), ssz.ErrLowBufferSize)
+}
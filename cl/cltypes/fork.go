@@ -4,6 +4,7 @@ import (
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon/cl/merkle_tree"
 	ssz2 "github.com/ledgerwatch/erigon/cl/ssz"
+	"github.com/ledgerwatch/erigon/cl/utils"
 )
 
 // Fork data, contains if we were on bellatrix/alteir/phase0 and transition epoch.
@@ -41,3 +42,36 @@ func (f *Fork) EncodingSizeSSZ() int {
 func (f *Fork) HashSSZ() ([32]byte, error) {
 	return merkle_tree.HashTreeRoot(f.PreviousVersion[:], f.CurrentVersion[:], f.Epoch)
 }
+
+// ForkData is the container whose root identifies a fork, used for fork digests and signing domains.
+type ForkData struct {
+	CurrentVersion        libcommon.Bytes4 `json:"current_version"`
+	GenesisValidatorsRoot libcommon.Hash   `json:"genesis_validators_root"`
+}
+
+func (*ForkData) Static() bool {
+	return true
+}
+
+func (f *ForkData) EncodeSSZ(dst []byte) ([]byte, error) {
+	return ssz2.MarshalSSZ(dst, f.CurrentVersion[:], f.GenesisValidatorsRoot[:])
+}
+
+func (f *ForkData) DecodeSSZ(buf []byte, _ int) error {
+	return ssz2.UnmarshalSSZ(buf, 0, f.CurrentVersion[:], f.GenesisValidatorsRoot[:])
+}
+
+func (f *ForkData) EncodingSizeSSZ() int {
+	return 36
+}
+
+func (f *ForkData) HashSSZ() ([32]byte, error) {
+	return merkle_tree.HashTreeRoot(f.CurrentVersion[:], f.GenesisValidatorsRoot[:])
+}
+
+// ComputeForkDataRoot returns the root of ForkData{version, genesisValidatorsRoot} without building the container.
+func ComputeForkDataRoot(version [4]byte, genesisValidatorsRoot [32]byte) [32]byte {
+	var version32 libcommon.Hash
+	copy(version32[:], version[:])
+	return utils.Sha256(version32[:], genesisValidatorsRoot[:])
+}
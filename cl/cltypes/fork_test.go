@@ -3,6 +3,7 @@ package cltypes_test
 import (
 	"testing"
 
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon/cl/cltypes"
 	"github.com/ledgerwatch/erigon/cl/merkle_tree"
 	ssz2 "github.com/ledgerwatch/erigon/cl/ssz"
@@ -100,3 +101,31 @@ func TestForkHashSSZ(t *testing.T) {
 
 	require.Equal(hash, expected, "Fork HashSSZ did not produce the expected result")
 }
+
+func TestForkDataHashSSZ(t *testing.T) {
+	require := require.New(t)
+
+	// Mainnet genesis validators root, with the phase0 and altair fork versions.
+	genesisValidatorsRoot := libcommon.HexToHash("0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95")
+	tests := []struct {
+		version  [4]byte
+		expected libcommon.Hash
+	}{
+		{version: [4]byte{0, 0, 0, 0}, expected: libcommon.HexToHash("0xb5303f2ad2010d699a76c8e62350947421a3e4a979779642cfdb0f6668986b25")},
+		{version: [4]byte{1, 0, 0, 0}, expected: libcommon.HexToHash("0xafcaaba0efab1ca832a15152469bb09bb84641c405171dfa2d3fb45f2bd8ddb9")},
+	}
+	for _, tt := range tests {
+		forkData := &cltypes.ForkData{CurrentVersion: tt.version, GenesisValidatorsRoot: genesisValidatorsRoot}
+		root, err := forkData.HashSSZ()
+		require.NoError(err)
+		require.Equal(tt.expected, libcommon.Hash(root))
+		require.Equal(root, cltypes.ComputeForkDataRoot(tt.version, genesisValidatorsRoot))
+
+		encoded, err := forkData.EncodeSSZ(nil)
+		require.NoError(err)
+		require.Len(encoded, forkData.EncodingSizeSSZ())
+		decoded := &cltypes.ForkData{}
+		require.NoError(decoded.DecodeSSZ(encoded, 0))
+		require.Equal(forkData, decoded)
+	}
+}
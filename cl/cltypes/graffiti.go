@@ -0,0 +1,61 @@
+package cltypes
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/ledgerwatch/erigon-lib/common/hexutility"
+	"github.com/ledgerwatch/erigon-lib/common/length"
+	"github.com/ledgerwatch/erigon-lib/types/clonable"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
+)
+
+// Graffiti is the arbitrary 32 bytes a proposer puts in its block body.
+type Graffiti [length.Hash]byte
+
+var _ ssz.EncodableSSZ = (*Graffiti)(nil)
+
+func (g *Graffiti) EncodeSSZ(dst []byte) ([]byte, error) {
+	return append(dst, g[:]...), nil
+}
+
+func (g *Graffiti) EncodeSSZTo(w io.Writer) (int, error) {
+	return w.Write(g[:])
+}
+
+func (g *Graffiti) DecodeSSZ(buf []byte, _ int) error {
+	if len(buf) < g.EncodingSizeSSZ() {
+		return fmt.Errorf("[Graffiti] err: %w", ssz.ErrLowBufferSize)
+	}
+	copy(g[:], buf)
+	return nil
+}
+
+func (g *Graffiti) EncodingSizeSSZ() int {
+	return length.Hash
+}
+
+// HashSSZ returns the root of the graffiti, a single chunk which is its own root.
+func (g *Graffiti) HashSSZ() ([32]byte, error) {
+	return *g, nil
+}
+
+func (*Graffiti) Static() bool {
+	return true
+}
+
+func (*Graffiti) Clone() clonable.Clonable {
+	return &Graffiti{}
+}
+
+func (g Graffiti) String() string {
+	return hexutility.Encode(g[:])
+}
+
+func (g Graffiti) MarshalText() ([]byte, error) {
+	return hexutility.Bytes(g[:]).MarshalText()
+}
+
+func (g *Graffiti) UnmarshalText(input []byte) error {
+	return hexutility.UnmarshalFixedText("Graffiti", input, g[:])
+}
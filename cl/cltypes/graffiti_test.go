@@ -0,0 +1,39 @@
+package cltypes_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+)
+
+func TestGraffiti(t *testing.T) {
+	var graffiti cltypes.Graffiti
+	copy(graffiti[:], "Caplin")
+
+	encoded, err := graffiti.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Len(t, encoded, graffiti.EncodingSizeSSZ())
+	decoded := cltypes.Graffiti{}
+	require.NoError(t, decoded.DecodeSSZ(encoded, 0))
+	require.Equal(t, graffiti, decoded)
+	require.ErrorIs(t, decoded.DecodeSSZ(encoded[:31], 0), ssz.ErrLowBufferSize)
+
+	// A single chunk is its own root.
+	root, err := graffiti.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, [32]byte(graffiti), root)
+
+	const expectedJSON = `"0x4361706c696e0000000000000000000000000000000000000000000000000000"`
+	j, err := json.Marshal(graffiti)
+	require.NoError(t, err)
+	require.Equal(t, expectedJSON, string(j))
+	decoded = cltypes.Graffiti{}
+	require.NoError(t, json.Unmarshal(j, &decoded))
+	require.Equal(t, graffiti, decoded)
+	require.Error(t, json.Unmarshal([]byte(`"0x4361706c696e"`), &decoded))
+	require.Equal(t, expectedJSON[1:len(expectedJSON)-1], graffiti.String())
+}
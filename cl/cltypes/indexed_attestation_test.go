@@ -0,0 +1,53 @@
+package cltypes_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
+)
+
+var testIndexedAttestation = &cltypes.IndexedAttestation{
+	AttestingIndices: solid.NewRawUint64List(2048, []uint64{1, 5, 9}),
+	Data: solid.NewAttestionDataFromParameters(
+		7,
+		2,
+		libcommon.HexToHash("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+		solid.NewCheckpointFromParameters(libcommon.HexToHash("0x0101010101010101010101010101010101010101010101010101010101010101"), 3),
+		solid.NewCheckpointFromParameters(libcommon.HexToHash("0x0202020202020202020202020202020202020202020202020202020202020202"), 4),
+	),
+	Signature: libcommon.Bytes96{1, 2, 3},
+}
+
+func TestIndexedAttestationSSZ(t *testing.T) {
+	encoded, err := testIndexedAttestation.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Len(t, encoded, testIndexedAttestation.EncodingSizeSSZ())
+	// 4 bytes offset + 128 bytes data + 96 bytes signature, then the indices.
+	require.Equal(t, uint32(228), binary.LittleEndian.Uint32(encoded))
+
+	decoded := cltypes.NewIndexedAttestation()
+	require.NoError(t, decoded.DecodeSSZ(encoded, 0))
+	require.Equal(t, 3, decoded.AttestingIndices.Length())
+	require.Equal(t, uint64(9), decoded.AttestingIndices.Get(2))
+	require.Equal(t, testIndexedAttestation.Data, decoded.Data)
+	require.Equal(t, testIndexedAttestation.Signature, decoded.Signature)
+
+	reencoded, err := decoded.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Equal(t, encoded, reencoded)
+}
+
+func TestIndexedAttestationHashSSZ(t *testing.T) {
+	dataRoot, err := testIndexedAttestation.Data.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, libcommon.HexToHash("0x889738b0ce769b164fd24370dbe6aad4154c627b2590a268dd00e78947bcedd3"), libcommon.Hash(dataRoot))
+
+	root, err := testIndexedAttestation.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, libcommon.HexToHash("0x0980ad807a52c27f59ecb2f4e32b253fc788e76e83693016545e6b45f7d00d8d"), libcommon.Hash(root))
+}
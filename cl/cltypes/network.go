@@ -65,7 +65,7 @@ type Ping struct {
 }
 
 func (p *Ping) EncodeSSZ(buf []byte) ([]byte, error) {
-	return append(buf, ssz.Uint64SSZ(p.Id)...), nil
+	return ssz.AppendUint64SSZ(buf, p.Id), nil
 }
 
 func (p *Ping) EncodingSizeSSZ() int {
@@ -0,0 +1,436 @@
+package cltypes_test
+
+import (
+	"math/rand"
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
+)
+
+// The property tests below are seeded: a failure is reproduced by running the failing subtest again.
+const (
+	propertyTestSeed   = 1337
+	propertyTestRounds = 32
+)
+
+type propertySSZ interface {
+	ssz.EncodableSSZ
+	ssz.HashableSSZ
+}
+
+// testPropertyRoundTripSSZ encodes random instances of a type and checks that decoding them into a fresh
+// object gives back the same encoding and the same root, and that hashing does not change the root.
+func testPropertyRoundTripSSZ(t *testing.T, version clparams.StateVersion, random func(rng *rand.Rand) propertySSZ, newObj func() propertySSZ) {
+	t.Helper()
+	rng := rand.New(rand.NewSource(propertyTestSeed))
+	for round := 0; round < propertyTestRounds; round++ {
+		obj := random(rng)
+		encoded, err := obj.EncodeSSZ(nil)
+		require.NoError(t, err)
+		require.Len(t, encoded, obj.EncodingSizeSSZ(), "round %d", round)
+		root, err := obj.HashSSZ()
+		require.NoError(t, err)
+		rootAgain, err := obj.HashSSZ()
+		require.NoError(t, err)
+		require.Equal(t, root, rootAgain, "round %d", round)
+
+		decoded := newObj()
+		require.NoError(t, decoded.DecodeSSZ(encoded, int(version)), "round %d", round)
+		reencoded, err := decoded.EncodeSSZ(nil)
+		require.NoError(t, err)
+		require.Equal(t, encoded, reencoded, "round %d", round)
+		decodedRoot, err := decoded.HashSSZ()
+		require.NoError(t, err)
+		require.Equal(t, root, decodedRoot, "round %d", round)
+	}
+}
+
+func randomBytes[T ~[]byte](rng *rand.Rand, n int) T {
+	b := make([]byte, n)
+	rng.Read(b)
+	return b
+}
+
+func randomHash(rng *rand.Rand) (h libcommon.Hash) {
+	rng.Read(h[:])
+	return
+}
+
+func randomBytes48(rng *rand.Rand) (b libcommon.Bytes48) {
+	rng.Read(b[:])
+	return
+}
+
+func randomBytes96(rng *rand.Rand) (b libcommon.Bytes96) {
+	rng.Read(b[:])
+	return
+}
+
+// randomBitlist returns a bitlist of up to maxBytes bytes whose last byte holds the delimiter bit.
+func randomBitlist(rng *rand.Rand, maxBytes int) []byte {
+	bits := randomBytes[[]byte](rng, 1+rng.Intn(maxBytes))
+	if bits[len(bits)-1] == 0 {
+		bits[len(bits)-1] = 1
+	}
+	return bits
+}
+
+func randomDepositData(rng *rand.Rand) *cltypes.DepositData {
+	return &cltypes.DepositData{
+		PubKey:                randomBytes48(rng),
+		WithdrawalCredentials: randomHash(rng),
+		Amount:                rng.Uint64(),
+		Signature:             randomBytes96(rng),
+	}
+}
+
+func randomDeposit(rng *rand.Rand) *cltypes.Deposit {
+	var branch [cltypes.DepositProofLength]libcommon.Hash
+	for i := range branch {
+		branch[i] = randomHash(rng)
+	}
+	return &cltypes.Deposit{Proof: cltypes.NewDepositProof(branch), Data: randomDepositData(rng)}
+}
+
+func randomSignedVoluntaryExit(rng *rand.Rand) *cltypes.SignedVoluntaryExit {
+	return &cltypes.SignedVoluntaryExit{
+		VoluntaryExit: &cltypes.VoluntaryExit{Epoch: rng.Uint64(), ValidatorIndex: rng.Uint64()},
+		Signature:     randomBytes96(rng),
+	}
+}
+
+func randomSignedBeaconBlockHeader(rng *rand.Rand) *cltypes.SignedBeaconBlockHeader {
+	return &cltypes.SignedBeaconBlockHeader{
+		Header: &cltypes.BeaconBlockHeader{
+			Slot:          rng.Uint64(),
+			ProposerIndex: rng.Uint64(),
+			ParentRoot:    randomHash(rng),
+			Root:          randomHash(rng),
+			BodyRoot:      randomHash(rng),
+		},
+		Signature: randomBytes96(rng),
+	}
+}
+
+func randomAttestationData(rng *rand.Rand) solid.AttestationData {
+	return solid.NewAttestionDataFromParameters(rng.Uint64(), rng.Uint64(), randomHash(rng),
+		solid.NewCheckpointFromParameters(randomHash(rng), rng.Uint64()),
+		solid.NewCheckpointFromParameters(randomHash(rng), rng.Uint64()))
+}
+
+func randomAttestation(rng *rand.Rand) *solid.Attestation {
+	return solid.NewAttestionFromParameters(randomBitlist(rng, 256), randomAttestationData(rng), randomBytes96(rng))
+}
+
+func randomIndexedAttestation(rng *rand.Rand) *cltypes.IndexedAttestation {
+	indices := make([]uint64, rng.Intn(64))
+	for i := range indices {
+		indices[i] = rng.Uint64()
+	}
+	return &cltypes.IndexedAttestation{
+		AttestingIndices: solid.NewRawUint64List(2048, indices),
+		Data:             randomAttestationData(rng),
+		Signature:        randomBytes96(rng),
+	}
+}
+
+func randomAttesterSlashing(rng *rand.Rand) *cltypes.AttesterSlashing {
+	return &cltypes.AttesterSlashing{Attestation_1: randomIndexedAttestation(rng), Attestation_2: randomIndexedAttestation(rng)}
+}
+
+func randomSignedBLSToExecutionChange(rng *rand.Rand) *cltypes.SignedBLSToExecutionChange {
+	change := &cltypes.BLSToExecutionChange{ValidatorIndex: rng.Uint64(), From: randomBytes48(rng)}
+	rng.Read(change.To[:])
+	return &cltypes.SignedBLSToExecutionChange{Message: change, Signature: randomBytes96(rng)}
+}
+
+func randomContribution(rng *rand.Rand) *cltypes.Contribution {
+	return &cltypes.Contribution{
+		Slot:              rng.Uint64(),
+		BeaconBlockRoot:   randomHash(rng),
+		SubcommitteeIndex: rng.Uint64(),
+		AggregationBits:   randomBytes[[]byte](rng, cltypes.SyncCommitteeAggregationBitsSize),
+		Signature:         randomBytes96(rng),
+	}
+}
+
+func randomSyncAggregate(rng *rand.Rand) *cltypes.SyncAggregate {
+	agg := &cltypes.SyncAggregate{SyncCommiteeSignature: randomBytes96(rng)}
+	rng.Read(agg.SyncCommiteeBits[:])
+	return agg
+}
+
+// randomBeaconBlock returns a block of the given version, phase0 or altair, with random operations in
+// every list of its body so that the offsets of all of them are exercised.
+func randomBeaconBlock(rng *rand.Rand, version clparams.StateVersion) *cltypes.SignedBeaconBlock {
+	block := cltypes.NewSignedBeaconBlock(&clparams.MainnetBeaconConfig)
+	block.Signature = randomBytes96(rng)
+	block.Block.Slot = rng.Uint64()
+	block.Block.ProposerIndex = rng.Uint64()
+	block.Block.ParentRoot = randomHash(rng)
+	block.Block.StateRoot = randomHash(rng)
+
+	body := block.Block.Body
+	body.Version = version
+	body.RandaoReveal = randomBytes96(rng)
+	body.Eth1Data = &cltypes.Eth1Data{Root: randomHash(rng), DepositCount: rng.Uint64(), BlockHash: randomHash(rng)}
+	rng.Read(body.Graffiti[:])
+	for i := rng.Intn(cltypes.MaxProposerSlashings + 1); i > 0; i-- {
+		body.ProposerSlashings.Append(&cltypes.ProposerSlashing{Header1: randomSignedBeaconBlockHeader(rng), Header2: randomSignedBeaconBlockHeader(rng)})
+	}
+	for i := rng.Intn(cltypes.MaxAttesterSlashings + 1); i > 0; i-- {
+		body.AttesterSlashings.Append(randomAttesterSlashing(rng))
+	}
+	for i := rng.Intn(8); i > 0; i-- {
+		body.Attestations.Append(randomAttestation(rng))
+	}
+	for i := rng.Intn(cltypes.MaxDeposits + 1); i > 0; i-- {
+		body.Deposits.Append(randomDeposit(rng))
+	}
+	for i := rng.Intn(cltypes.MaxVoluntaryExits + 1); i > 0; i-- {
+		body.VoluntaryExits.Append(randomSignedVoluntaryExit(rng))
+	}
+	if version >= clparams.AltairVersion {
+		body.SyncAggregate = randomSyncAggregate(rng)
+	}
+	return block
+}
+
+// randomStatic fills a fixed size object from random bytes: any bytes of the right length are a valid
+// encoding of the types it is used for.
+func randomStatic(rng *rand.Rand, obj propertySSZ, version clparams.StateVersion) propertySSZ {
+	if err := obj.DecodeSSZ(randomBytes[[]byte](rng, obj.EncodingSizeSSZ()), int(version)); err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+func TestPropertyRoundTripSSZ(t *testing.T) {
+	tests := []struct {
+		name    string
+		version clparams.StateVersion
+		random  func(rng *rand.Rand) propertySSZ
+		newObj  func() propertySSZ
+	}{
+		{
+			name: "DepositMessage",
+			random: func(rng *rand.Rand) propertySSZ {
+				return &cltypes.DepositMessage{PubKey: randomBytes48(rng), WithdrawalCredentials: randomHash(rng), Amount: rng.Uint64()}
+			},
+			newObj: func() propertySSZ { return &cltypes.DepositMessage{} },
+		},
+		{
+			name:   "DepositData",
+			random: func(rng *rand.Rand) propertySSZ { return randomDepositData(rng) },
+			newObj: func() propertySSZ { return &cltypes.DepositData{} },
+		},
+		{
+			name:   "Deposit",
+			random: func(rng *rand.Rand) propertySSZ { return randomDeposit(rng) },
+			newObj: func() propertySSZ { return &cltypes.Deposit{} },
+		},
+		{
+			name:   "SignedVoluntaryExit",
+			random: func(rng *rand.Rand) propertySSZ { return randomSignedVoluntaryExit(rng) },
+			newObj: func() propertySSZ { return &cltypes.SignedVoluntaryExit{} },
+		},
+		{
+			name:   "SignedBeaconBlockHeader",
+			random: func(rng *rand.Rand) propertySSZ { return randomSignedBeaconBlockHeader(rng) },
+			newObj: func() propertySSZ { return &cltypes.SignedBeaconBlockHeader{} },
+		},
+		{
+			name: "ProposerSlashing",
+			random: func(rng *rand.Rand) propertySSZ {
+				return &cltypes.ProposerSlashing{Header1: randomSignedBeaconBlockHeader(rng), Header2: randomSignedBeaconBlockHeader(rng)}
+			},
+			newObj: func() propertySSZ { return &cltypes.ProposerSlashing{} },
+		},
+		{
+			name:   "IndexedAttestation",
+			random: func(rng *rand.Rand) propertySSZ { return randomIndexedAttestation(rng) },
+			newObj: func() propertySSZ { return cltypes.NewIndexedAttestation() },
+		},
+		{
+			name:   "AttesterSlashing",
+			random: func(rng *rand.Rand) propertySSZ { return randomAttesterSlashing(rng) },
+			newObj: func() propertySSZ { return cltypes.NewAttesterSlashing() },
+		},
+		{
+			name:   "Attestation",
+			random: func(rng *rand.Rand) propertySSZ { return randomAttestation(rng) },
+			newObj: func() propertySSZ { return &solid.Attestation{} },
+		},
+		{
+			name: "SignedAggregateAndProof",
+			random: func(rng *rand.Rand) propertySSZ {
+				return &cltypes.SignedAggregateAndProof{
+					Message:   &cltypes.AggregateAndProof{AggregatorIndex: rng.Uint64(), Aggregate: randomAttestation(rng), SelectionProof: randomBytes96(rng)},
+					Signature: randomBytes96(rng),
+				}
+			},
+			newObj: func() propertySSZ { return &cltypes.SignedAggregateAndProof{} },
+		},
+		{
+			name: "Eth1Data",
+			random: func(rng *rand.Rand) propertySSZ {
+				return &cltypes.Eth1Data{Root: randomHash(rng), DepositCount: rng.Uint64(), BlockHash: randomHash(rng)}
+			},
+			newObj: func() propertySSZ { return &cltypes.Eth1Data{} },
+		},
+		{
+			name: "Fork",
+			random: func(rng *rand.Rand) propertySSZ {
+				fork := &cltypes.Fork{Epoch: rng.Uint64()}
+				rng.Read(fork.PreviousVersion[:])
+				rng.Read(fork.CurrentVersion[:])
+				return fork
+			},
+			newObj: func() propertySSZ { return &cltypes.Fork{} },
+		},
+		{
+			name: "ForkData",
+			random: func(rng *rand.Rand) propertySSZ {
+				forkData := &cltypes.ForkData{GenesisValidatorsRoot: randomHash(rng)}
+				rng.Read(forkData.CurrentVersion[:])
+				return forkData
+			},
+			newObj: func() propertySSZ { return &cltypes.ForkData{} },
+		},
+		{
+			name:   "SignedBLSToExecutionChange",
+			random: func(rng *rand.Rand) propertySSZ { return randomSignedBLSToExecutionChange(rng) },
+			newObj: func() propertySSZ { return &cltypes.SignedBLSToExecutionChange{} },
+		},
+		{
+			name: "Withdrawal",
+			random: func(rng *rand.Rand) propertySSZ {
+				withdrawal := &cltypes.Withdrawal{Index: rng.Uint64(), Validator: rng.Uint64(), Amount: rng.Uint64()}
+				rng.Read(withdrawal.Address[:])
+				return withdrawal
+			},
+			newObj: func() propertySSZ { return &cltypes.Withdrawal{} },
+		},
+		{
+			name: "HistoricalSummary",
+			random: func(rng *rand.Rand) propertySSZ {
+				return &cltypes.HistoricalSummary{BlockSummaryRoot: randomHash(rng), StateSummaryRoot: randomHash(rng)}
+			},
+			newObj: func() propertySSZ { return &cltypes.HistoricalSummary{} },
+		},
+		{
+			name: "BlobIdentifier",
+			random: func(rng *rand.Rand) propertySSZ {
+				return cltypes.NewBlobIdentifier(randomHash(rng), rng.Uint64())
+			},
+			newObj: func() propertySSZ { return &cltypes.BlobIdentifier{} },
+		},
+		{
+			name:   "SyncAggregate",
+			random: func(rng *rand.Rand) propertySSZ { return randomSyncAggregate(rng) },
+			newObj: func() propertySSZ { return &cltypes.SyncAggregate{} },
+		},
+		{
+			name: "SyncAggregatorSelectionData",
+			random: func(rng *rand.Rand) propertySSZ {
+				return &cltypes.SyncAggregatorSelectionData{Slot: rng.Uint64(), SubcommitteeIndex: rng.Uint64()}
+			},
+			newObj: func() propertySSZ { return &cltypes.SyncAggregatorSelectionData{} },
+		},
+		{
+			name: "SyncCommitteeMessage",
+			random: func(rng *rand.Rand) propertySSZ {
+				return &cltypes.SyncCommitteeMessage{Slot: rng.Uint64(), BeaconBlockRoot: randomHash(rng), ValidatorIndex: rng.Uint64(), Signature: randomBytes96(rng)}
+			},
+			newObj: func() propertySSZ { return &cltypes.SyncCommitteeMessage{} },
+		},
+		{
+			name: "SignedContributionAndProof",
+			random: func(rng *rand.Rand) propertySSZ {
+				return &cltypes.SignedContributionAndProof{
+					Message:   &cltypes.ContributionAndProof{AggregatorIndex: rng.Uint64(), Contribution: randomContribution(rng), SelectionProof: randomBytes96(rng)},
+					Signature: randomBytes96(rng),
+				}
+			},
+			newObj: func() propertySSZ { return &cltypes.SignedContributionAndProof{} },
+		},
+		{
+			name: "SyncCommittee",
+			random: func(rng *rand.Rand) propertySSZ {
+				return randomStatic(rng, &solid.SyncCommittee{}, clparams.AltairVersion)
+			},
+			newObj: func() propertySSZ { return &solid.SyncCommittee{} },
+		},
+		{
+			name:    "LightClientBootstrap",
+			version: clparams.AltairVersion,
+			random: func(rng *rand.Rand) propertySSZ {
+				return randomStatic(rng, cltypes.NewLightClientBootstrap(clparams.AltairVersion), clparams.AltairVersion)
+			},
+			newObj: func() propertySSZ { return cltypes.NewLightClientBootstrap(clparams.AltairVersion) },
+		},
+		{
+			name:    "LightClientUpdate",
+			version: clparams.AltairVersion,
+			random: func(rng *rand.Rand) propertySSZ {
+				return randomStatic(rng, cltypes.NewLightClientUpdate(clparams.AltairVersion), clparams.AltairVersion)
+			},
+			newObj: func() propertySSZ { return cltypes.NewLightClientUpdate(clparams.AltairVersion) },
+		},
+		{
+			name:    "LightClientFinalityUpdate",
+			version: clparams.AltairVersion,
+			random: func(rng *rand.Rand) propertySSZ {
+				return randomStatic(rng, cltypes.NewLightClientFinalityUpdate(clparams.AltairVersion), clparams.AltairVersion)
+			},
+			newObj: func() propertySSZ { return cltypes.NewLightClientFinalityUpdate(clparams.AltairVersion) },
+		},
+		{
+			name:    "LightClientOptimisticUpdate",
+			version: clparams.AltairVersion,
+			random: func(rng *rand.Rand) propertySSZ {
+				return randomStatic(rng, cltypes.NewLightClientOptimisticUpdate(clparams.AltairVersion), clparams.AltairVersion)
+			},
+			newObj: func() propertySSZ { return cltypes.NewLightClientOptimisticUpdate(clparams.AltairVersion) },
+		},
+		{
+			name:    "SignedBeaconBlock phase0",
+			version: clparams.Phase0Version,
+			random:  func(rng *rand.Rand) propertySSZ { return randomBeaconBlock(rng, clparams.Phase0Version) },
+			newObj:  func() propertySSZ { return cltypes.NewSignedBeaconBlock(&clparams.MainnetBeaconConfig) },
+		},
+		{
+			name:    "SignedBeaconBlock altair",
+			version: clparams.AltairVersion,
+			random:  func(rng *rand.Rand) propertySSZ { return randomBeaconBlock(rng, clparams.AltairVersion) },
+			newObj:  func() propertySSZ { return cltypes.NewSignedBeaconBlock(&clparams.MainnetBeaconConfig) },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testPropertyRoundTripSSZ(t, tt.version, tt.random, tt.newObj)
+		})
+	}
+}
+
+// TestPropertyRoundTripSSZEqual checks decode(encode(x)) == x with Equal for the types defining it.
+func TestPropertyRoundTripSSZEqual(t *testing.T) {
+	rng := rand.New(rand.NewSource(propertyTestSeed))
+	for round := 0; round < propertyTestRounds; round++ {
+		deposit := randomDeposit(rng)
+		exit := randomSignedVoluntaryExit(rng)
+		header := randomSignedBeaconBlockHeader(rng)
+		testRoundTripSSZ(t, deposit.Data, &cltypes.DepositData{})
+		testRoundTripSSZ(t, deposit, &cltypes.Deposit{})
+		testRoundTripSSZ(t, exit.VoluntaryExit, &cltypes.VoluntaryExit{})
+		testRoundTripSSZ(t, exit, &cltypes.SignedVoluntaryExit{})
+		testRoundTripSSZ(t, header.Header, &cltypes.BeaconBlockHeader{})
+		testRoundTripSSZ(t, &cltypes.Eth1Data{Root: randomHash(rng), DepositCount: rng.Uint64(), BlockHash: randomHash(rng)}, &cltypes.Eth1Data{})
+	}
+}
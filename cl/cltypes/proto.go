@@ -0,0 +1,80 @@
+package cltypes
+
+import (
+	"fmt"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces"
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/sentinel"
+)
+
+// ToProto converts the deposit data to its protobuf message of the internal RPC.
+func (d *DepositData) ToProto() *sentinel.DepositData {
+	return &sentinel.DepositData{
+		Pubkey:                libcommon.Copy(d.PubKey[:]),
+		WithdrawalCredentials: gointerfaces.ConvertHashToH256(d.WithdrawalCredentials),
+		Amount:                d.Amount,
+		Signature:             libcommon.Copy(d.Signature[:]),
+	}
+}
+
+// FromProto sets the deposit data from its protobuf message of the internal RPC.
+func (d *DepositData) FromProto(msg *sentinel.DepositData) error {
+	if msg == nil || msg.WithdrawalCredentials == nil {
+		return fmt.Errorf("[DepositData] err: incomplete message")
+	}
+	if len(msg.Pubkey) != len(d.PubKey) {
+		return fmt.Errorf("[DepositData] err: public key of %d bytes", len(msg.Pubkey))
+	}
+	if len(msg.Signature) != len(d.Signature) {
+		return fmt.Errorf("[DepositData] err: signature of %d bytes", len(msg.Signature))
+	}
+	copy(d.PubKey[:], msg.Pubkey)
+	d.WithdrawalCredentials = gointerfaces.ConvertH256ToHash(msg.WithdrawalCredentials)
+	d.Amount = msg.Amount
+	copy(d.Signature[:], msg.Signature)
+	return nil
+}
+
+// ToProto converts the voluntary exit to its protobuf message of the internal RPC.
+func (e *VoluntaryExit) ToProto() *sentinel.VoluntaryExit {
+	return &sentinel.VoluntaryExit{
+		Epoch:          e.Epoch,
+		ValidatorIndex: e.ValidatorIndex,
+	}
+}
+
+// FromProto sets the voluntary exit from its protobuf message of the internal RPC.
+func (e *VoluntaryExit) FromProto(msg *sentinel.VoluntaryExit) error {
+	if msg == nil {
+		return fmt.Errorf("[VoluntaryExit] err: nil message")
+	}
+	e.Epoch = msg.Epoch
+	e.ValidatorIndex = msg.ValidatorIndex
+	return nil
+}
+
+// ToProto converts the signed voluntary exit to its protobuf message of the internal RPC.
+func (e *SignedVoluntaryExit) ToProto() *sentinel.SignedVoluntaryExit {
+	return &sentinel.SignedVoluntaryExit{
+		VoluntaryExit: e.VoluntaryExit.ToProto(),
+		Signature:     libcommon.Copy(e.Signature[:]),
+	}
+}
+
+// FromProto sets the signed voluntary exit from its protobuf message of the internal RPC.
+func (e *SignedVoluntaryExit) FromProto(msg *sentinel.SignedVoluntaryExit) error {
+	if msg == nil {
+		return fmt.Errorf("[SignedVoluntaryExit] err: nil message")
+	}
+	if len(msg.Signature) != len(e.Signature) {
+		return fmt.Errorf("[SignedVoluntaryExit] err: signature of %d bytes", len(msg.Signature))
+	}
+	exit := &VoluntaryExit{}
+	if err := exit.FromProto(msg.VoluntaryExit); err != nil {
+		return err
+	}
+	e.VoluntaryExit = exit
+	copy(e.Signature[:], msg.Signature)
+	return nil
+}
@@ -0,0 +1,47 @@
+package cltypes_test
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/gointerfaces/sentinel"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+)
+
+func TestDepositDataProto(t *testing.T) {
+	depositData := &cltypes.DepositData{PubKey: [48]byte{1, 47: 2}, WithdrawalCredentials: [32]byte{3, 31: 4}, Amount: 32000000000, Signature: [96]byte{5, 95: 6}}
+
+	// Through the wire format of the internal RPC.
+	wire, err := proto.Marshal(depositData.ToProto())
+	require.NoError(t, err)
+	msg := &sentinel.DepositData{}
+	require.NoError(t, proto.Unmarshal(wire, msg))
+
+	decoded := &cltypes.DepositData{}
+	require.NoError(t, decoded.FromProto(msg))
+	require.Equal(t, depositData, decoded)
+
+	require.Error(t, decoded.FromProto(nil))
+	require.Error(t, decoded.FromProto(&sentinel.DepositData{Pubkey: msg.Pubkey, Signature: msg.Signature}))
+	require.Error(t, decoded.FromProto(&sentinel.DepositData{Pubkey: msg.Pubkey[1:], WithdrawalCredentials: msg.WithdrawalCredentials, Signature: msg.Signature}))
+	require.Error(t, decoded.FromProto(&sentinel.DepositData{Pubkey: msg.Pubkey, WithdrawalCredentials: msg.WithdrawalCredentials}))
+}
+
+func TestSignedVoluntaryExitProto(t *testing.T) {
+	exit := &cltypes.SignedVoluntaryExit{VoluntaryExit: &cltypes.VoluntaryExit{Epoch: 5, ValidatorIndex: 10}, Signature: [96]byte{1, 95: 2}}
+
+	wire, err := proto.Marshal(exit.ToProto())
+	require.NoError(t, err)
+	msg := &sentinel.SignedVoluntaryExit{}
+	require.NoError(t, proto.Unmarshal(wire, msg))
+
+	decoded := &cltypes.SignedVoluntaryExit{}
+	require.NoError(t, decoded.FromProto(msg))
+	require.Equal(t, exit, decoded)
+
+	require.Error(t, decoded.FromProto(nil))
+	require.Error(t, decoded.FromProto(&sentinel.SignedVoluntaryExit{Signature: msg.Signature}))
+	require.Error(t, decoded.FromProto(&sentinel.SignedVoluntaryExit{VoluntaryExit: msg.VoluntaryExit, Signature: msg.Signature[:95]}))
+}
@@ -0,0 +1,83 @@
+package cltypes
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
+	"github.com/ledgerwatch/erigon/cl/gossip"
+	ssz2 "github.com/ledgerwatch/erigon/cl/ssz"
+)
+
+// Registry maps message type names to factories of empty objects, so that messages can be decoded
+// knowing only their name, e.g. a gossip topic.
+type Registry struct {
+	mu        sync.RWMutex
+	factories map[string]func() ssz.Unmarshaler
+}
+
+func NewRegistry() *Registry {
+	return &Registry{factories: make(map[string]func() ssz.Unmarshaler)}
+}
+
+// NewGossipRegistry returns a registry of the objects of every gossip topic. Subnet topics are registered
+// under their name prefix, e.g. gossip.TopicNamePrefixBeaconAttestation, and are found by any subnet name.
+func NewGossipRegistry(beaconCfg *clparams.BeaconChainConfig) *Registry {
+	r := NewRegistry()
+	r.Register(gossip.TopicNameBeaconBlock, func() ssz.Unmarshaler { return NewSignedBeaconBlock(beaconCfg) })
+	r.Register(gossip.TopicNameBeaconAggregateAndProof, func() ssz.Unmarshaler { return &SignedAggregateAndProof{} })
+	r.Register(gossip.TopicNameVoluntaryExit, func() ssz.Unmarshaler { return &SignedVoluntaryExit{} })
+	r.Register(gossip.TopicNameProposerSlashing, func() ssz.Unmarshaler { return &ProposerSlashing{} })
+	r.Register(gossip.TopicNameAttesterSlashing, func() ssz.Unmarshaler { return &AttesterSlashing{} })
+	r.Register(gossip.TopicNameBlsToExecutionChange, func() ssz.Unmarshaler { return &SignedBLSToExecutionChange{} })
+	r.Register(gossip.TopicNameSyncCommitteeContributionAndProof, func() ssz.Unmarshaler { return &SignedContributionAndProof{} })
+	r.Register(gossip.TopicNameLightClientFinalityUpdate, func() ssz.Unmarshaler { return &LightClientFinalityUpdate{} })
+	r.Register(gossip.TopicNameLightClientOptimisticUpdate, func() ssz.Unmarshaler { return &LightClientOptimisticUpdate{} })
+	r.Register(gossip.TopicNamePrefixBlobSidecar, func() ssz.Unmarshaler { return &BlobSidecar{} })
+	r.Register(gossip.TopicNamePrefixBeaconAttestation, func() ssz.Unmarshaler { return &solid.Attestation{} })
+	r.Register(gossip.TopicNamePrefixSyncCommittee, func() ssz.Unmarshaler { return &SyncCommitteeMessage{} })
+	return r
+}
+
+// Register sets the factory of the objects of the given name, replacing any previous one.
+func (r *Registry) Register(name string, factory func() ssz.Unmarshaler) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[name] = factory
+}
+
+// Decode decodes buf into a new object of the given name. Objects supporting it are decoded strictly, as
+// messages from the network should be.
+func (r *Registry) Decode(name string, buf []byte, version clparams.StateVersion) (ssz.Unmarshaler, error) {
+	r.mu.RLock()
+	factory, ok := r.factories[registryName(name)]
+	r.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("[Registry] err: unknown message type %q", name)
+	}
+	obj := factory()
+	decode := obj.DecodeSSZ
+	if strictObj, ok := obj.(ssz2.StrictUnmarshaler); ok {
+		decode = strictObj.DecodeSSZStrict
+	}
+	if err := decode(buf, int(version)); err != nil {
+		return nil, fmt.Errorf("[Registry] %s: %w", name, err)
+	}
+	return obj, nil
+}
+
+// registryName maps the topic of a subnet, e.g. beacon_attestation_3, to the prefix it is registered with.
+func registryName(name string) string {
+	switch {
+	case gossip.IsTopicBeaconAttestation(name):
+		return gossip.TopicNamePrefixBeaconAttestation
+	case gossip.IsTopicSyncCommittee(name):
+		return gossip.TopicNamePrefixSyncCommittee
+	case gossip.IsTopicBlobSidecar(name):
+		return gossip.TopicNamePrefixBlobSidecar
+	}
+	return name
+}
@@ -0,0 +1,84 @@
+package cltypes_test
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
+	"github.com/ledgerwatch/erigon/cl/gossip"
+)
+
+func TestGossipRegistry(t *testing.T) {
+	attestation := solid.NewAttestionFromParameters([]byte{0x0b}, solid.NewAttestationData(), [96]byte{1})
+	block := cltypes.NewSignedBeaconBlock(&clparams.MainnetBeaconConfig)
+	block.Block.Slot = 7
+	attesterSlashing := cltypes.NewAttesterSlashing()
+	attesterSlashing.Attestation_1.AttestingIndices.Append(1)
+	attesterSlashing.Attestation_2.AttestingIndices.Append(2)
+
+	tests := map[string]ssz.Marshaler{
+		gossip.TopicNameBeaconBlock:                       block,
+		gossip.TopicNameBeaconAggregateAndProof:           &cltypes.SignedAggregateAndProof{Message: &cltypes.AggregateAndProof{AggregatorIndex: 3, Aggregate: attestation}},
+		gossip.TopicNameVoluntaryExit:                     &cltypes.SignedVoluntaryExit{VoluntaryExit: &cltypes.VoluntaryExit{Epoch: 1, ValidatorIndex: 2}},
+		gossip.TopicNameProposerSlashing:                  zeroEncoding(416),
+		gossip.TopicNameAttesterSlashing:                  attesterSlashing,
+		gossip.TopicNameBlsToExecutionChange:              zeroEncoding(172),
+		gossip.TopicNameSyncCommitteeContributionAndProof: zeroEncoding(360),
+		gossip.TopicNameLightClientFinalityUpdate:         cltypes.NewLightClientFinalityUpdate(clparams.AltairVersion),
+		gossip.TopicNameLightClientOptimisticUpdate:       cltypes.NewLightClientOptimisticUpdate(clparams.AltairVersion),
+		gossip.TopicNameBeaconAttestation(5):              attestation,
+		gossip.TopicNameSyncCommittee(2):                  &cltypes.SyncCommitteeMessage{Slot: 4, ValidatorIndex: 9},
+	}
+	registry := cltypes.NewGossipRegistry(&clparams.MainnetBeaconConfig)
+	for name, obj := range tests {
+		t.Run(name, func(t *testing.T) {
+			encoded, err := obj.EncodeSSZ(nil)
+			require.NoError(t, err)
+			version := clparams.Phase0Version
+			if gossip.IsTopicSyncCommittee(name) || name == gossip.TopicNameSyncCommitteeContributionAndProof ||
+				name == gossip.TopicNameLightClientFinalityUpdate || name == gossip.TopicNameLightClientOptimisticUpdate {
+				version = clparams.AltairVersion
+			}
+			decoded, err := registry.Decode(name, encoded, version)
+			require.NoError(t, err)
+			reencoded, err := decoded.(ssz.Marshaler).EncodeSSZ(nil)
+			require.NoError(t, err)
+			require.Equal(t, encoded, reencoded)
+		})
+	}
+
+	// Subnet topics are found by any subnet.
+	encoded, err := attestation.EncodeSSZ(nil)
+	require.NoError(t, err)
+	decoded, err := registry.Decode(gossip.TopicNameBeaconAttestation(63), encoded, clparams.Phase0Version)
+	require.NoError(t, err)
+	require.IsType(t, &solid.Attestation{}, decoded)
+
+	// Gossiped attestations are decoded strictly: the delimiter bit is required.
+	encoded[len(encoded)-1] = 0
+	_, err = registry.Decode(gossip.TopicNameBeaconAttestation(5), encoded, clparams.Phase0Version)
+	require.ErrorIs(t, err, ssz.ErrNonCanonical)
+
+	_, err = registry.Decode("unknown_topic", encoded, clparams.Phase0Version)
+	require.ErrorContains(t, err, "unknown message type")
+
+	registry.Register("unknown_topic", func() ssz.Unmarshaler { return &cltypes.Fork{} })
+	decoded, err = registry.Decode("unknown_topic", make([]byte, 16), clparams.Phase0Version)
+	require.NoError(t, err)
+	require.IsType(t, &cltypes.Fork{}, decoded)
+}
+
+// zeroEncoding is the encoding of a fixed size object with all its fields zero.
+type zeroEncoding int
+
+func (z zeroEncoding) EncodeSSZ(dst []byte) ([]byte, error) {
+	return append(dst, make([]byte, z)...), nil
+}
+
+func (z zeroEncoding) EncodingSizeSSZ() int {
+	return int(z)
+}
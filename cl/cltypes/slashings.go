@@ -1,6 +1,7 @@
 package cltypes
 
 import (
+	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
 	"github.com/ledgerwatch/erigon/cl/merkle_tree"
 	ssz2 "github.com/ledgerwatch/erigon/cl/ssz"
 )
@@ -21,13 +22,18 @@ func (p *ProposerSlashing) DecodeSSZ(buf []byte, version int) error {
 }
 
 func (p *ProposerSlashing) EncodingSizeSSZ() int {
-	return p.Header1.EncodingSizeSSZ() * 2
+	return new(SignedBeaconBlockHeader).EncodingSizeSSZ() * 2
 }
 
 func (p *ProposerSlashing) HashSSZ() ([32]byte, error) {
 	return merkle_tree.HashTreeRoot(p.Header1, p.Header2)
 }
 
+// NewProposerSlashingList returns an empty SSZ list of proposer slashings, as carried by a block body.
+func NewProposerSlashingList() *solid.ListSSZ[*ProposerSlashing] {
+	return solid.NewStaticListSSZ[*ProposerSlashing](MaxProposerSlashings, new(ProposerSlashing).EncodingSizeSSZ())
+}
+
 type AttesterSlashing struct {
 	Attestation_1 *IndexedAttestation `json:"attestation_1"`
 	Attestation_2 *IndexedAttestation `json:"attestation_2"`
@@ -57,3 +63,10 @@ func (a *AttesterSlashing) EncodingSizeSSZ() int {
 func (a *AttesterSlashing) HashSSZ() ([32]byte, error) {
 	return merkle_tree.HashTreeRoot(a.Attestation_1, a.Attestation_2)
 }
+
+// NewAttesterSlashingList returns an empty SSZ list of attester slashings, as carried by a block body.
+// Attester slashings are variable size because of their attesting indices, so the list is encoded with
+// an offset table.
+func NewAttesterSlashingList() *solid.ListSSZ[*AttesterSlashing] {
+	return solid.NewDynamicListSSZ[*AttesterSlashing](MaxAttesterSlashings)
+}
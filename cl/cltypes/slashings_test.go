@@ -1,6 +1,8 @@
 package cltypes
 
 import (
+	"bytes"
+	"encoding/binary"
 	"testing"
 
 	"github.com/ledgerwatch/erigon-lib/common"
@@ -81,3 +83,127 @@ func TestAttesterSlashing(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, expectedRoot, common.Hash(root))
 }
+
+func TestAttesterSlashingPopulated(t *testing.T) {
+	newAttestation := func() *IndexedAttestation {
+		return &IndexedAttestation{
+			AttestingIndices: solid.NewRawUint64List(2048, []uint64{1, 5, 9}),
+			Data: solid.NewAttestionDataFromParameters(
+				7,
+				2,
+				common.HexToHash("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+				solid.NewCheckpointFromParameters(common.HexToHash("0x0101010101010101010101010101010101010101010101010101010101010101"), 3),
+				solid.NewCheckpointFromParameters(common.HexToHash("0x0202020202020202020202020202020202020202020202020202020202020202"), 4),
+			),
+			Signature: common.Bytes96{1, 2, 3},
+		}
+	}
+	attesterSlashing := &AttesterSlashing{
+		Attestation_1: newAttestation(),
+		Attestation_2: newAttestation(),
+	}
+
+	encodedData, err := attesterSlashing.EncodeSSZ(nil)
+	assert.NoError(t, err)
+	assert.Len(t, encodedData, attesterSlashing.EncodingSizeSSZ())
+
+	decodedAttesterSlashing := &AttesterSlashing{}
+	assert.NoError(t, decodedAttesterSlashing.DecodeSSZ(encodedData, 0))
+	reencodedData, err := decodedAttesterSlashing.EncodeSSZ(nil)
+	assert.NoError(t, err)
+	assert.Equal(t, encodedData, reencodedData)
+
+	root, err := attesterSlashing.HashSSZ()
+	assert.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0x303be208f7163f4d616130eb06ce8f3320d1357aca314e9066b7217f43dbf403"), common.Hash(root))
+}
+
+func TestProposerSlashingList(t *testing.T) {
+	newHeader := func(i int, bodyRoot common.Hash) *SignedBeaconBlockHeader {
+		return &SignedBeaconBlockHeader{
+			Header: &BeaconBlockHeader{
+				Slot:          uint64(i),
+				ProposerIndex: uint64(i + 1),
+				ParentRoot:    common.Hash{byte(i)},
+				BodyRoot:      bodyRoot,
+			},
+			Signature: common.Bytes96{byte(i)},
+		}
+	}
+	expectedRoots := map[int]string{
+		0:                    "0x792930bbd5baac43bcc798ee49aa8185ef76bb3b44ba62b91d86ae569e4bb535",
+		1:                    "0x69d61845a6b8220ac65534e5757ce9f3589eafa36db8a9258127c4c7bfd739cf",
+		MaxProposerSlashings: "0xd261cf23c67684499e9ca626bef76a59048739074c8a643c7e98574ad63f994e",
+	}
+	for count, expectedRoot := range expectedRoots {
+		slashings := NewProposerSlashingList()
+		for i := 0; i < count; i++ {
+			slashings.Append(&ProposerSlashing{Header1: newHeader(i, common.Hash{}), Header2: newHeader(i, common.Hash{0xff})})
+		}
+		root, err := slashings.HashSSZ()
+		assert.NoError(t, err)
+		assert.Equal(t, common.HexToHash(expectedRoot), common.Hash(root))
+
+		// The elements are fixed size, so they are encoded back to back without offsets.
+		encoded, err := slashings.EncodeSSZ(nil)
+		assert.NoError(t, err)
+		assert.Len(t, encoded, count*416)
+		decoded := NewProposerSlashingList()
+		assert.NoError(t, decoded.DecodeSSZ(encoded, 0))
+		assert.Equal(t, count, decoded.Len())
+		decodedRoot, err := decoded.HashSSZ()
+		assert.NoError(t, err)
+		assert.Equal(t, root, decodedRoot)
+	}
+
+	// One slashing over the limit, and a truncated slashing.
+	assert.Error(t, NewProposerSlashingList().DecodeSSZ(make([]byte, (MaxProposerSlashings+1)*416), 0))
+	assert.Error(t, NewProposerSlashingList().DecodeSSZ(make([]byte, 415), 0))
+}
+
+func TestAttesterSlashingList(t *testing.T) {
+	newAttestation := func(indices []uint64, slot int, blockRoot byte) *IndexedAttestation {
+		return &IndexedAttestation{
+			AttestingIndices: solid.NewRawUint64List(2048, indices),
+			Data: solid.NewAttestionDataFromParameters(
+				uint64(slot),
+				0,
+				common.BytesToHash(bytes.Repeat([]byte{blockRoot}, 32)),
+				solid.NewCheckpointFromParameters(common.HexToHash("0x0101010101010101010101010101010101010101010101010101010101010101"), 1),
+				solid.NewCheckpointFromParameters(common.HexToHash("0x0202020202020202020202020202020202020202020202020202020202020202"), 2),
+			),
+			Signature: common.Bytes96{byte(slot)},
+		}
+	}
+	expectedRoots := map[int]string{
+		0:                    "0x7a0501f5957bdf9cb3a8ff4966f02265f968658b7a9c62642cba1165e86642f5",
+		1:                    "0x833ac2698cece0a2e871c0803cbd8814ae3dcd2f7d1c0ea1a9f81b792689ed4c",
+		MaxAttesterSlashings: "0xf7c1f94effc3f47742fc9e9b242778fdf285b6a046d5035ae3377af9363cd9de",
+	}
+	for count, expectedRoot := range expectedRoots {
+		slashings := NewAttesterSlashingList()
+		for i := 0; i < count; i++ {
+			slashings.Append(&AttesterSlashing{
+				Attestation_1: newAttestation([]uint64{uint64(i), uint64(i + 5)}, i, 0xaa),
+				Attestation_2: newAttestation([]uint64{uint64(i + 1)}, i, 0xbb),
+			})
+		}
+		root, err := slashings.HashSSZ()
+		assert.NoError(t, err)
+		assert.Equal(t, common.HexToHash(expectedRoot), common.Hash(root))
+
+		encoded, err := slashings.EncodeSSZ(nil)
+		assert.NoError(t, err)
+		assert.Len(t, encoded, slashings.EncodingSizeSSZ())
+		// The elements are variable size, so the list starts with a table of their offsets.
+		if count > 0 {
+			assert.Equal(t, uint32(4*count), binary.LittleEndian.Uint32(encoded))
+		}
+		decoded := NewAttesterSlashingList()
+		assert.NoError(t, decoded.DecodeSSZ(encoded, 0))
+		assert.Equal(t, count, decoded.Len())
+		decodedRoot, err := decoded.HashSSZ()
+		assert.NoError(t, err)
+		assert.Equal(t, root, decodedRoot)
+	}
+}
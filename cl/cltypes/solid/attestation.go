@@ -2,6 +2,8 @@ package solid
 
 import (
 	"encoding/json"
+	"fmt"
+	"math/bits"
 
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/common/hexutility"
@@ -19,6 +21,9 @@ const (
 
 	// offset is usually always the same
 	aggregationBitsOffset = 228
+
+	// aggregationBitsLimit is the maximum number of aggregation bits, MAX_VALIDATORS_PER_COMMITTEE.
+	aggregationBitsLimit = 2048
 )
 
 // Attestation type represents a statement or confirmation of some occurrence or phenomenon.
@@ -82,9 +87,22 @@ func (a *Attestation) AggregationBits() []byte {
 
 // SetAggregationBits sets the aggregation bits buffer of the Attestation instance.
 func (a *Attestation) SetAggregationBits(bits []byte) {
+	ssz.EncodeOffset(a.staticBuffer[:4], aggregationBitsOffset)
 	a.aggregationBitsBuffer = bits
 }
 
+// AggregationBitsCount returns the number of set bits in the aggregation bitlist, not counting its length bit.
+func (a *Attestation) AggregationBitsCount() int {
+	count := 0
+	for _, b := range a.aggregationBitsBuffer {
+		count += bits.OnesCount8(b)
+	}
+	if count > 0 {
+		count--
+	}
+	return count
+}
+
 // AttestantionData returns the attestation data of the Attestation instance.
 func (a *Attestation) AttestantionData() AttestationData {
 	return (AttestationData)(a.staticBuffer[4:132])
@@ -125,10 +143,27 @@ func (a *Attestation) DecodeSSZ(buf []byte, _ int) error {
 	return nil
 }
 
+// DecodeSSZStrict is DecodeSSZ for untrusted input: it also rejects an aggregation bits offset other than
+// the end of the fixed part and aggregation bits that are not a canonical bitlist.
+func (a *Attestation) DecodeSSZStrict(buf []byte, version int) error {
+	if len(buf) < attestationStaticBufferSize {
+		return ssz.ErrLowBufferSize
+	}
+	if offset := ssz.DecodeOffset(buf); offset != aggregationBitsOffset {
+		return fmt.Errorf("[Attestation] err: aggregation bits offset %d: %w", offset, ssz.ErrBadOffset)
+	}
+	if err := ssz.CheckBitlist(buf[aggregationBitsOffset:], aggregationBitsLimit); err != nil {
+		return fmt.Errorf("[Attestation] err: aggregation bits: %w", err)
+	}
+	return a.DecodeSSZ(buf, version)
+}
+
 // EncodeSSZ encodes the Attestation instance into the provided buffer.
 func (a *Attestation) EncodeSSZ(dst []byte) ([]byte, error) {
 	buf := dst
-	buf = append(buf, a.staticBuffer[:]...)
+	// The aggregation bits are the only dynamic field, so their offset is always the same.
+	buf = append(buf, ssz.OffsetSSZ(aggregationBitsOffset)...)
+	buf = append(buf, a.staticBuffer[4:]...)
 	buf = append(buf, a.aggregationBitsBuffer...)
 	return buf, nil
 }
@@ -138,7 +173,7 @@ func (a *Attestation) CopyHashBufferTo(o []byte) error {
 	for i := 0; i < 128; i++ {
 		o[i] = 0
 	}
-	aggBytesRoot, err := merkle_tree.BitlistRootWithLimit(a.AggregationBits(), 2048)
+	aggBytesRoot, err := merkle_tree.BitlistRootWithLimit(a.AggregationBits(), aggregationBitsLimit)
 	if err != nil {
 		return err
 	}
@@ -1,9 +1,11 @@
 package solid
 
 import (
+	"encoding/binary"
 	"testing"
 
 	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -91,3 +93,77 @@ func TestAttestation(t *testing.T) {
 	cloned := attestation.Clone()
 	assert.NotEqual(t, nil, cloned.(*Attestation))
 }
+
+func TestAttestationHashSSZ(t *testing.T) {
+	data := NewAttestionDataFromParameters(
+		7,
+		2,
+		common.HexToHash("0xaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"),
+		NewCheckpointFromParameters(common.HexToHash("0x0101010101010101010101010101010101010101010101010101010101010101"), 3),
+		NewCheckpointFromParameters(common.HexToHash("0x0202020202020202020202020202020202020202020202020202020202020202"), 4),
+	)
+	// Bits 1, 1, 0 followed by the length bit.
+	attestation := NewAttestionFromParameters([]byte{0x0b}, data, [96]byte{1, 2, 3})
+	assert.Equal(t, 2, attestation.AggregationBitsCount())
+
+	root, err := attestation.HashSSZ()
+	assert.NoError(t, err)
+	assert.Equal(t, common.HexToHash("0xe03696ba473c6f4cb112f85859d384e1fd135d8243b2433c3adcc29a3f041693"), common.Hash(root))
+}
+
+func TestAttestationEncodeSSZOffset(t *testing.T) {
+	for _, aggregationBits := range [][]byte{{0x01}, {0xff, 0x01}, {0xff, 0xff, 0xff, 0x80}, make([]byte, 257)} {
+		aggregationBits[len(aggregationBits)-1] |= 0x80
+		attestation := NewAttestionFromParameters(aggregationBits, NewAttestationData(), [96]byte{})
+		encoded, err := attestation.EncodeSSZ(nil)
+		assert.NoError(t, err)
+		assert.Len(t, encoded, attestation.EncodingSizeSSZ())
+		assert.Equal(t, uint32(aggregationBitsOffset), binary.LittleEndian.Uint32(encoded[:4]))
+
+		decoded := &Attestation{}
+		assert.NoError(t, decoded.DecodeSSZ(encoded, 0))
+		assert.Equal(t, aggregationBits, decoded.AggregationBits())
+		assert.Equal(t, attestation.AggregationBitsCount(), decoded.AggregationBitsCount())
+	}
+}
+
+func TestAttestationDecodeSSZStrict(t *testing.T) {
+	encode := func(aggregationBits []byte) []byte {
+		encoded, err := NewAttestionFromParameters(aggregationBits, NewAttestationData(), [96]byte{1}).EncodeSSZ(nil)
+		assert.NoError(t, err)
+		return encoded
+	}
+	full := make([]byte, 257)
+	full[256] = 0x01
+
+	tests := []struct {
+		name   string
+		buf    []byte
+		strict error
+	}{
+		{name: "canonical", buf: encode([]byte{0x05})},
+		{name: "canonical multi byte", buf: encode([]byte{0xff, 0x80})},
+		{name: "full committee", buf: encode(full)},
+		{name: "trailing zero byte", buf: encode([]byte{0x05, 0x00}), strict: ssz.ErrNonCanonical},
+		{name: "no delimiter bit", buf: encode([]byte{0x00}), strict: ssz.ErrNonCanonical},
+		{name: "empty bits", buf: encode(nil), strict: ssz.ErrNonCanonical},
+		{name: "too many bits", buf: encode(append(make([]byte, 256), 0x02)), strict: ssz.ErrTooBigList},
+		{name: "bad offset", buf: func() []byte {
+			buf := encode([]byte{0x05})
+			binary.LittleEndian.PutUint32(buf, aggregationBitsOffset-1)
+			return buf
+		}(), strict: ssz.ErrBadOffset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.NoError(t, (&Attestation{}).DecodeSSZ(tt.buf, 0))
+			err := (&Attestation{}).DecodeSSZStrict(tt.buf, 0)
+			if tt.strict == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.strict)
+		})
+	}
+	assert.ErrorIs(t, (&Attestation{}).DecodeSSZStrict(make([]byte, 10), 0), ssz.ErrLowBufferSize)
+}
@@ -6,8 +6,8 @@ import (
 
 	"github.com/ledgerwatch/erigon-lib/common/hexutility"
 	"github.com/ledgerwatch/erigon-lib/types/clonable"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
 	"github.com/ledgerwatch/erigon/cl/merkle_tree"
-	"github.com/ledgerwatch/erigon/cl/utils"
 )
 
 // BitList is like a dynamic binary string. It's like a flipbook of 1s and 0s!
@@ -126,8 +126,7 @@ func (u *BitList) HashSSZ() ([32]byte, error) {
 			return baseRoot, err
 		}
 	}
-	lengthRoot := merkle_tree.Uint64Root(uint64(u.l))
-	return utils.Sha256(baseRoot[:], lengthRoot[:]), nil
+	return merkle_tree.MixInLength(baseRoot, uint64(u.l)), nil
 }
 
 func (arr *BitList) getBaseHash(xs []byte, depth uint8) error {
@@ -163,6 +162,9 @@ func (u *BitList) EncodeSSZ(dst []byte) ([]byte, error) {
 // DecodeSSZ replaces the underlying byte slice of the BitList with a copy of the input byte slice.
 // It then updates the length of the BitList to match the length of the new byte slice.
 func (u *BitList) DecodeSSZ(dst []byte, _ int) error {
+	if err := ssz.CheckListLimit(uint64(len(dst)), uint64(u.c)); err != nil {
+		return err
+	}
 	u.u = make([]byte, len(dst))
 	copy(u.u, dst)
 	u.l = len(dst)
@@ -120,9 +120,12 @@ func (c Checkpoint) Equal(other Checkpoint) bool {
 	return bytes.Equal(c, other)
 }
 
-// CopyHashBufferTo copies the hash of the Checkpoint to the buffer 'o'.
+// CopyHashBufferTo copies the hash leaves of the Checkpoint to the buffer 'o', which may be reused.
 func (c Checkpoint) CopyHashBufferTo(o []byte) error {
 	copy(o[:32], c[:8])
+	for i := 8; i < 32; i++ {
+		o[i] = 0
+	}
 	copy(o[32:], c[8:])
 	return nil
 }
@@ -29,3 +29,25 @@ func TestCheckpointHashTreeRoot(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, root[:], expectedTestCheckpointRoot)
 }
+
+func TestCheckpointCopyHashBufferToReusedBuffer(t *testing.T) {
+	buf := make([]byte, 64)
+	for i := range buf {
+		buf[i] = 0xff
+	}
+	require.NoError(t, testCheckpoint.CopyHashBufferTo(buf))
+	expected := make([]byte, 64)
+	expected[0] = 69
+	expected[63] = 3
+	assert.Equal(t, expected, buf)
+}
+
+func TestCheckpointEqual(t *testing.T) {
+	other := testCheckpoint.Copy()
+	assert.True(t, testCheckpoint.Equal(other))
+	other.SetEpoch(70)
+	assert.False(t, testCheckpoint.Equal(other))
+	other = testCheckpoint.Copy()
+	other.SetBlockRoot(libcommon.HexToHash("0x4"))
+	assert.False(t, testCheckpoint.Equal(other))
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/ledgerwatch/erigon-lib/types/clonable"
 	"github.com/ledgerwatch/erigon-lib/types/ssz"
 	"github.com/ledgerwatch/erigon/cl/merkle_tree"
-	"github.com/ledgerwatch/erigon/cl/utils"
 )
 
 type hashList struct {
@@ -155,8 +154,7 @@ func (h *hashList) HashSSZ() ([32]byte, error) {
 			return [32]byte{}, err
 		}
 	}
-	lengthRoot := merkle_tree.Uint64Root(uint64(h.l))
-	return utils.Sha256(baseRoot[:], lengthRoot[:]), nil
+	return merkle_tree.MixInLength(baseRoot, uint64(h.l)), nil
 }
 
 func (h *hashList) Range(fn func(int, libcommon.Hash, int) bool) {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -130,3 +131,19 @@ func TestUint64VectorSSZ(t *testing.T) {
 	encodingSize := arr.EncodingSizeSSZ()
 	assert.Equal(t, expectedEncodingSize, encodingSize)
 }
+
+func TestListSSZDecodeOverLimit(t *testing.T) {
+	// The first offset of a dynamic list gives its element count: this one claims about a billion attestations.
+	buf := make([]byte, 64)
+	ssz.EncodeOffset(buf, 0xfffffffc)
+	list := NewDynamicListSSZ[*Attestation](128)
+	allocs := testing.AllocsPerRun(10, func() {
+		assert.ErrorIs(t, list.DecodeSSZ(buf, 0), ssz.ErrTooBigList)
+	})
+	assert.Zero(t, allocs)
+
+	assert.ErrorIs(t, NewStaticListSSZ[Checkpoint](1, CheckpointSize).DecodeSSZ(make([]byte, 2*CheckpointSize), 0), ssz.ErrTooBigList)
+	assert.ErrorIs(t, NewUint64ListSSZ(2).DecodeSSZ(make([]byte, 3*8), 0), ssz.ErrTooBigList)
+	assert.ErrorIs(t, NewRawUint64List(2, nil).DecodeSSZ(make([]byte, 3*8), 0), ssz.ErrTooBigList)
+	assert.ErrorIs(t, NewBitList(0, 2).DecodeSSZ(make([]byte, 3), 0), ssz.ErrTooBigList)
+}
@@ -0,0 +1,195 @@
+package solid
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/length"
+	"github.com/ledgerwatch/erigon-lib/types/clonable"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
+	"github.com/ledgerwatch/erigon/cl/merkle_tree"
+)
+
+// RandaoMixes is the randao_mixes vector of the beacon state, one mix per epoch of the historical vector.
+// Every block changes a single mix, so the vector keeps its merkle tree: a root only rehashes the paths of
+// the mixes set since the previous one, at the cost of about twice the memory of the mixes.
+type RandaoMixes struct {
+	mixes [][32]byte
+	tree  *merkle_tree.CachedTree
+}
+
+var _ HashVectorSSZ = (*RandaoMixes)(nil)
+
+// NewRandaoMixes returns a vector of length zero mixes, EPOCHS_PER_HISTORICAL_VECTOR in a beacon state.
+func NewRandaoMixes(length int) *RandaoMixes {
+	r := &RandaoMixes{
+		mixes: make([][32]byte, length),
+		tree:  merkle_tree.NewCachedTree(uint64(length)),
+	}
+	r.resetTree()
+	return r
+}
+
+// resetTree rebuilds the tree over the mixes, it cannot fail as there are exactly as many mixes as the
+// limit of the tree.
+func (r *RandaoMixes) resetTree() {
+	if err := r.tree.Reset(r.mixes); err != nil {
+		panic(err)
+	}
+}
+
+func (r *RandaoMixes) Length() int {
+	return len(r.mixes)
+}
+
+func (r *RandaoMixes) Cap() int {
+	return len(r.mixes)
+}
+
+// Clear zeroes every mix.
+func (r *RandaoMixes) Clear() {
+	for i := range r.mixes {
+		r.mixes[i] = [32]byte{}
+	}
+	r.resetTree()
+}
+
+func (r *RandaoMixes) Append(libcommon.Hash) {
+	panic("RandaoMixes -- Append: not implemented")
+}
+
+func (r *RandaoMixes) Pop() libcommon.Hash {
+	panic("RandaoMixes -- Pop: not implemented")
+}
+
+func (r *RandaoMixes) Range(fn func(index int, mix libcommon.Hash, length int) bool) {
+	for i := range r.mixes {
+		if !fn(i, r.mixes[i], len(r.mixes)) {
+			return
+		}
+	}
+}
+
+// Bytes returns a copy of the mixes in their SSZ encoding.
+func (r *RandaoMixes) Bytes() []byte {
+	out, _ := r.EncodeSSZ(make([]byte, 0, r.EncodingSizeSSZ()))
+	return out
+}
+
+func (r *RandaoMixes) Get(index int) libcommon.Hash {
+	if index < 0 || index >= len(r.mixes) {
+		panic("RandaoMixes -- Get: out of bounds")
+	}
+	return r.mixes[index]
+}
+
+func (r *RandaoMixes) Set(index int, mix libcommon.Hash) {
+	if index < 0 || index >= len(r.mixes) {
+		panic("RandaoMixes -- Set: out of bounds")
+	}
+	r.mixes[index] = mix
+	r.tree.MarkDirty(uint64(index))
+}
+
+// CopyTo copies the mixes to t, resized to the length of r. The tree of t is rebuilt on its next root.
+// t is either another RandaoMixes or a plain hash vector, as kept by the fork choice store.
+func (r *RandaoMixes) CopyTo(target IterableSSZ[libcommon.Hash]) {
+	if v, ok := target.(*hashVector); ok {
+		r.copyToHashVector(v)
+		return
+	}
+	t := target.(*RandaoMixes)
+	if len(t.mixes) != len(r.mixes) {
+		t.mixes = make([][32]byte, len(r.mixes))
+		t.tree = merkle_tree.NewCachedTree(uint64(len(r.mixes)))
+	}
+	copy(t.mixes, r.mixes)
+	t.resetTree()
+}
+
+func (r *RandaoMixes) copyToHashVector(v *hashVector) {
+	size := len(r.mixes) * length.Hash
+	if len(v.u.u) < size {
+		v.u.u = make([]byte, size)
+	}
+	for i := range r.mixes {
+		copy(v.u.u[i*length.Hash:], r.mixes[i][:])
+	}
+	v.u.l = len(r.mixes)
+	v.u.c = int(merkle_tree.NextPowerOfTwo(uint64(len(r.mixes))))
+}
+
+func (r *RandaoMixes) EncodeSSZ(dst []byte) ([]byte, error) {
+	for i := range r.mixes {
+		dst = append(dst, r.mixes[i][:]...)
+	}
+	return dst, nil
+}
+
+func (r *RandaoMixes) EncodeSSZTo(w io.Writer) (int, error) {
+	written := 0
+	for i := range r.mixes {
+		n, err := w.Write(r.mixes[i][:])
+		written += n
+		if err != nil {
+			return written, err
+		}
+	}
+	return written, nil
+}
+
+func (r *RandaoMixes) DecodeSSZ(buf []byte, _ int) error {
+	if len(buf) < r.EncodingSizeSSZ() {
+		return fmt.Errorf("[RandaoMixes] err: %w", ssz.ErrLowBufferSize)
+	}
+	if len(buf) > r.EncodingSizeSSZ() {
+		return fmt.Errorf("[RandaoMixes] err: %w: expected %d bytes, got %d", ssz.ErrBufferTooLong, r.EncodingSizeSSZ(), len(buf))
+	}
+	for i := range r.mixes {
+		copy(r.mixes[i][:], buf[i*length.Hash:])
+	}
+	r.resetTree()
+	return nil
+}
+
+func (r *RandaoMixes) EncodingSizeSSZ() int {
+	return len(r.mixes) * length.Hash
+}
+
+func (r *RandaoMixes) HashSSZ() ([32]byte, error) {
+	return r.tree.Root()
+}
+
+func (*RandaoMixes) Static() bool {
+	return true
+}
+
+func (r *RandaoMixes) Clone() clonable.Clonable {
+	return NewRandaoMixes(len(r.mixes))
+}
+
+func (r *RandaoMixes) MarshalJSON() ([]byte, error) {
+	list := make([]libcommon.Hash, len(r.mixes))
+	for i := range r.mixes {
+		list[i] = r.mixes[i]
+	}
+	return json.Marshal(list)
+}
+
+// UnmarshalJSON sets the mixes from a JSON list of exactly as many mixes as the vector holds.
+func (r *RandaoMixes) UnmarshalJSON(buf []byte) error {
+	var list []libcommon.Hash
+	if err := json.Unmarshal(buf, &list); err != nil {
+		return err
+	}
+	if len(list) != len(r.mixes) {
+		return fmt.Errorf("[RandaoMixes] err: %d mixes for a vector of %d", len(list), len(r.mixes))
+	}
+	for i := range list {
+		r.mixes[i] = list[i]
+	}
+	r.resetTree()
+	return nil
+}
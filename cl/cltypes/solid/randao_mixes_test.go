@@ -0,0 +1,87 @@
+package solid
+
+import (
+	"bytes"
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
+	"github.com/stretchr/testify/require"
+)
+
+func repeatedHash(b byte) libcommon.Hash {
+	return libcommon.BytesToHash(bytes.Repeat([]byte{b}, 32))
+}
+
+func TestRandaoMixes(t *testing.T) {
+	// EPOCHS_PER_HISTORICAL_VECTOR on mainnet.
+	mixes := NewRandaoMixes(65536)
+	require.Equal(t, 2097152, mixes.EncodingSizeSSZ())
+	mixes.Set(0, repeatedHash(0x01))
+	mixes.Set(1, repeatedHash(0x02))
+	mixes.Set(65535, repeatedHash(0xff))
+	root, err := mixes.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, libcommon.HexToHash("0x80e8f95a6b4ba6c626e566c5d21edfc888f2987359e2a79a053ee7a4fea34cae"), libcommon.Hash(root))
+
+	// Only the path of the new mix is rehashed, the root is the one of the whole vector.
+	mixes.Set(1000, repeatedHash(0x03))
+	root, err = mixes.HashSSZ()
+	require.NoError(t, err)
+	expected := libcommon.HexToHash("0x3067343301f4fc77cd54d445eeb3050601efc3e3b7c1e2255684762314bc96f4")
+	require.Equal(t, expected, libcommon.Hash(root))
+	require.Equal(t, repeatedHash(0x03), mixes.Get(1000))
+
+	encoded, err := mixes.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Len(t, encoded, mixes.EncodingSizeSSZ())
+	decoded := NewRandaoMixes(65536)
+	require.NoError(t, decoded.DecodeSSZ(encoded, 0))
+	root, err = decoded.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, expected, libcommon.Hash(root))
+	require.ErrorIs(t, decoded.DecodeSSZ(encoded[:len(encoded)-1], 0), ssz.ErrLowBufferSize)
+	require.ErrorIs(t, decoded.DecodeSSZ(append(encoded, 0), 0), ssz.ErrBufferTooLong)
+
+	copied := NewRandaoMixes(8)
+	mixes.CopyTo(copied)
+	root, err = copied.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, expected, libcommon.Hash(root))
+	// The copy does not share the mixes of the original.
+	copied.Set(0, libcommon.Hash{})
+	require.Equal(t, repeatedHash(0x01), mixes.Get(0))
+
+	require.Panics(t, func() { mixes.Set(65536, libcommon.Hash{}) })
+
+	// The fork choice store copies the mixes of states to plain hash vectors.
+	vector := NewHashVector(8)
+	mixes.CopyTo(vector)
+	require.Equal(t, 65536, vector.Length())
+	require.Equal(t, repeatedHash(0x03), vector.Get(1000))
+	vectorRoot, err := vector.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, expected, libcommon.Hash(vectorRoot))
+}
+
+func TestRandaoMixesJSON(t *testing.T) {
+	mixes := NewRandaoMixes(4)
+	mixes.Set(1, repeatedHash(0x01))
+	encoded, err := mixes.MarshalJSON()
+	require.NoError(t, err)
+	vector := NewHashVector(4)
+	vector.Set(1, repeatedHash(0x01))
+	vectorEncoded, err := vector.MarshalJSON()
+	require.NoError(t, err)
+	require.JSONEq(t, string(vectorEncoded), string(encoded))
+
+	decoded := NewRandaoMixes(4)
+	require.NoError(t, decoded.UnmarshalJSON(encoded))
+	require.Equal(t, mixes.Bytes(), decoded.Bytes())
+	root, err := decoded.HashSSZ()
+	require.NoError(t, err)
+	expected, err := vector.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+	require.Error(t, NewRandaoMixes(8).UnmarshalJSON(encoded))
+}
@@ -0,0 +1,36 @@
+package solid
+
+import "github.com/ledgerwatch/erigon-lib/types/clonable"
+
+// Slashings is the slashings vector of the beacon state: the effective balance slashed in each of the last
+// EPOCHS_PER_SLASHINGS_VECTOR epochs. The underlying uint64 vector caches the roots of its chunks, so a
+// root after a few segments changed only rehashes their chunks.
+type Slashings struct {
+	Uint64VectorSSZ
+}
+
+// NewSlashings returns a vector of length zero segments.
+func NewSlashings(length int) *Slashings {
+	return &Slashings{Uint64VectorSSZ: NewUint64VectorSSZ(length)}
+}
+
+// Total returns the sum of the segments, the total slashed balance used by process_slashings.
+func (s *Slashings) Total() (total uint64) {
+	s.Range(func(_ int, segment uint64, _ int) bool {
+		total += segment
+		return true
+	})
+	return
+}
+
+// CopyTo copies the segments to t, another Slashings or a plain uint64 vector.
+func (s *Slashings) CopyTo(t IterableSSZ[uint64]) {
+	if ts, ok := t.(*Slashings); ok {
+		t = ts.Uint64VectorSSZ
+	}
+	s.Uint64VectorSSZ.CopyTo(t)
+}
+
+func (s *Slashings) Clone() clonable.Clonable {
+	return NewSlashings(s.Length())
+}
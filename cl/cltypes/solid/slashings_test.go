@@ -0,0 +1,38 @@
+package solid
+
+import (
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSlashings(t *testing.T) {
+	// EPOCHS_PER_SLASHINGS_VECTOR on mainnet.
+	slashings := NewSlashings(8192)
+	require.Equal(t, 65536, slashings.EncodingSizeSSZ())
+	// Root once so that the roots of the chunks are cached before the segments change.
+	root, err := slashings.HashSSZ()
+	require.NoError(t, err)
+	slashings.Set(0, 1000000000)
+	slashings.Set(5, 32000000000)
+	slashings.Set(8191, 7)
+	root, err = slashings.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, libcommon.HexToHash("0xaf57dccd1f1526f08e964fb01da532218b9646eb010ee47b8ec341b23d9c98d9"), libcommon.Hash(root))
+	require.Equal(t, uint64(33000000007), slashings.Total())
+
+	encoded, err := slashings.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Len(t, encoded, 65536)
+	decoded := NewSlashings(8192)
+	require.NoError(t, decoded.DecodeSSZ(encoded, 0))
+	decodedRoot, err := decoded.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, root, decodedRoot)
+
+	copied := NewSlashings(8192)
+	slashings.CopyTo(copied)
+	require.Equal(t, uint64(33000000007), copied.Total())
+	require.IsType(t, &Slashings{}, slashings.Clone())
+}
@@ -1,92 +1,335 @@
 package solid
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+	"sync"
 
+	"github.com/Giulio2002/bls"
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/length"
 	"github.com/ledgerwatch/erigon-lib/types/clonable"
 	"github.com/ledgerwatch/erigon-lib/types/ssz"
 	"github.com/ledgerwatch/erigon/cl/merkle_tree"
+	"github.com/ledgerwatch/erigon/cl/utils"
+	"github.com/prysmaticlabs/gohashtree"
+	"golang.org/x/sync/errgroup"
 )
 
-// Whole committee(512) public key and the aggregate public key.
+// Whole committee(512) public key and the aggregate public key: the encoding size of a mainnet committee,
+// which is what the zero value of SyncCommittee holds.
 const syncCommitteeSize = 48 * 513
 
-type SyncCommittee [syncCommitteeSize]byte
+// emptyMainnetSyncCommittee is the encoding read from the zero value of SyncCommittee. It is never written.
+var emptyMainnetSyncCommittee = make([]byte, syncCommitteeSize)
 
+type SyncCommittee struct {
+	// keys is the encoding of the committee: the public keys of the members followed by the aggregate one.
+	// It is sized by the number of members the committee was created with, and nil in the zero value, which
+	// stands for an empty mainnet committee until its first write.
+	keys []byte
+
+	// mu is held by every write of the keys and by root computations, so that a root is never computed
+	// from half-written keys. It also guards the cached root, which every mutation of the keys drops, and
+	// the tree of the members. Getters and encoders do not take it: reading the keys while they are being
+	// mutated is still up to the caller to avoid.
+	mu        sync.Mutex
+	root      [32]byte
+	rootValid bool
+	// pubKeysLeaves are the roots of the member public keys, the leaves of pubKeysTree. They are kept
+	// so that SetPubKey only rehashes the path from the key it replaces; any other mutation of the
+	// members drops them and the tree is rebuilt on the next root computation.
+	pubKeysLeaves [][32]byte
+	pubKeysTree   *merkle_tree.CachedTree
+}
+
+var (
+	_ ssz.EncodableSSZ = (*SyncCommittee)(nil)
+	_ ssz.HashableSSZ  = (*SyncCommittee)(nil)
+)
+
+// NewSyncCommittee returns an empty sync committee of size members, the SyncCommitteeSize of the beacon config.
+func NewSyncCommittee(size int) *SyncCommittee {
+	return &SyncCommittee{keys: make([]byte, (size+1)*48)}
+}
+
+// NewSyncCommitteeFromParameters returns a sync committee of the given members and aggregate public key.
 func NewSyncCommitteeFromParameters(
 	committee []libcommon.Bytes48,
 	aggregatePublicKey libcommon.Bytes48,
 ) *SyncCommittee {
-	s := &SyncCommittee{}
+	s := NewSyncCommittee(len(committee))
 	s.SetAggregatePublicKey(aggregatePublicKey)
 	s.SetCommittee(committee)
 	return s
 }
 
+// encoding returns the keys of the committee, for reading only.
+func (s *SyncCommittee) encoding() []byte {
+	if s.keys == nil {
+		return emptyMainnetSyncCommittee
+	}
+	return s.keys
+}
+
+// writableKeys returns the keys of the committee, allocating those of the zero value. It must be called
+// with s.mu held.
+func (s *SyncCommittee) writableKeys() []byte {
+	if s.keys == nil {
+		s.keys = make([]byte, syncCommitteeSize)
+	}
+	return s.keys
+}
+
+// syncCommitteeMembers is the number of public keys in the committee.
+func (s *SyncCommittee) syncCommitteeMembers() int {
+	return len(s.encoding())/48 - 1
+}
+
 func (s *SyncCommittee) GetCommittee() []libcommon.Bytes48 {
-	committee := make([]libcommon.Bytes48, 512)
+	committee := make([]libcommon.Bytes48, s.syncCommitteeMembers())
 	for i := range committee {
-		copy(committee[i][:], s[i*48:])
+		copy(committee[i][:], s.encoding()[i*48:])
 	}
 	return committee
 }
 
+// ParticipatingPubkeys returns the public keys of the committee members whose bit is set in the
+// given sync committee bitvector, in committee order. bits must cover exactly the committee members.
+func (s *SyncCommittee) ParticipatingPubkeys(bits []byte) ([]libcommon.Bytes48, error) {
+	members := s.syncCommitteeMembers()
+	if len(bits) != (members+7)/8 {
+		return nil, fmt.Errorf("[SyncCommittee] err: bad bits length: expected %d bytes, got %d", (members+7)/8, len(bits))
+	}
+	var participants []libcommon.Bytes48
+	for i := 0; i < members; i++ {
+		if bits[i/8]&(1<<(i%8)) == 0 {
+			continue
+		}
+		var pubkey libcommon.Bytes48
+		copy(pubkey[:], s.encoding()[i*48:])
+		participants = append(participants, pubkey)
+	}
+	return participants, nil
+}
+
+// AggregatePubkeys BLS-aggregates the public keys of the participants marked in bits, which is the
+// key a sync aggregate signature verifies against. As in the spec's eth_aggregate_pubkeys, there is
+// no aggregate of an empty set, so an error is returned when nobody participated.
+func (s *SyncCommittee) AggregatePubkeys(bits []byte) (libcommon.Bytes48, error) {
+	participants, err := s.ParticipatingPubkeys(bits)
+	if err != nil {
+		return libcommon.Bytes48{}, err
+	}
+	if len(participants) == 0 {
+		return libcommon.Bytes48{}, fmt.Errorf("[SyncCommittee] err: no participants to aggregate")
+	}
+	keys := make([][]byte, len(participants))
+	for i := range participants {
+		keys[i] = participants[i][:]
+	}
+	aggregate, err := bls.AggregatePublickKeys(keys)
+	if err != nil {
+		return libcommon.Bytes48{}, err
+	}
+	var out libcommon.Bytes48
+	copy(out[:], aggregate)
+	return out, nil
+}
+
 func (s *SyncCommittee) AggregatePublicKey() (out libcommon.Bytes48) {
-	copy(out[:], s[syncCommitteeSize-48:])
+	copy(out[:], s.encoding()[s.syncCommitteeMembers()*48:])
 	return
 }
 
 func (s *SyncCommittee) SetCommittee(committee []libcommon.Bytes48) {
-	for i := range committee {
-		copy(s[i*48:], committee[i][:])
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := 0; i < len(committee) && i < s.syncCommitteeMembers(); i++ {
+		copy(s.writableKeys()[i*48:], committee[i][:])
+	}
+	s.keysChanged()
+}
+
+// SetPubKey replaces the public key of the committee member at index. Once the tree of the members has
+// been built by a root computation, only the path from the replaced key is rehashed by the next one.
+func (s *SyncCommittee) SetPubKey(index int, key libcommon.Bytes48) error {
+	if index < 0 || index >= s.syncCommitteeMembers() {
+		return fmt.Errorf("[SyncCommittee] err: member index %d out of range [0, %d)", index, s.syncCommitteeMembers())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copy(s.writableKeys()[index*48:], key[:])
+	s.rootValid = false
+	if s.pubKeysTree != nil {
+		s.pubKeysLeaves[index] = merkle_tree.PublicKeyRoot(key)
+		s.pubKeysTree.MarkDirty(uint64(index))
 	}
+	return nil
 }
 
 func (s *SyncCommittee) SetAggregatePublicKey(k libcommon.Bytes48) {
-	copy(s[syncCommitteeSize-48:], k[:])
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copy(s.writableKeys()[s.syncCommitteeMembers()*48:], k[:])
+	// The members are unchanged: their tree stays valid.
+	s.rootValid = false
+}
+
+// keysChanged drops the cached root and the tree of the members after a mutation of the keys. It must be
+// called with s.mu held.
+func (s *SyncCommittee) keysChanged() {
+	s.rootValid = false
+	s.pubKeysLeaves, s.pubKeysTree = nil, nil
 }
 
 func (s *SyncCommittee) EncodingSizeSSZ() int {
-	return syncCommitteeSize
+	return (s.syncCommitteeMembers() + 1) * 48
 }
 
 func (s *SyncCommittee) DecodeSSZ(buf []byte, _ int) error {
 	if len(buf) < s.EncodingSizeSSZ() {
-		return ssz.ErrLowBufferSize
+		return fmt.Errorf("[SyncCommittee] err: %w", ssz.ErrLowBufferSize)
+	}
+	if len(buf) > s.EncodingSizeSSZ() {
+		return fmt.Errorf("[SyncCommittee] err: %w: expected %d bytes, got %d", ssz.ErrBufferTooLong, s.EncodingSizeSSZ(), len(buf))
 	}
-	copy(s[:], buf)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	copy(s.writableKeys(), buf)
+	s.keysChanged()
 	return nil
 }
 
 func (s *SyncCommittee) EncodeSSZ(dst []byte) ([]byte, error) {
-	return append(dst, s[:]...), nil
+	return append(dst, s.encoding()...), nil
+}
+
+func (s *SyncCommittee) EncodeSSZTo(w io.Writer) (int, error) {
+	return w.Write(s.encoding())
 }
 
 func (s *SyncCommittee) Clone() clonable.Clonable {
-	return &SyncCommittee{}
+	if s.keys == nil {
+		return &SyncCommittee{}
+	}
+	return NewSyncCommittee(s.syncCommitteeMembers())
 }
 
+// Copy returns a copy of the committee, along with its cached root. The tree of the members is not
+// copied: the copy builds its own on its first root computation after a SetPubKey.
 func (s *SyncCommittee) Copy() *SyncCommittee {
-	t := &SyncCommittee{}
-	copy(t[:], s[:])
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t := &SyncCommittee{keys: libcommon.Copy(s.keys)}
+	t.root, t.rootValid = s.root, s.rootValid
 	return t
 }
 
 func (s *SyncCommittee) Equal(o *SyncCommittee) bool {
-	return *s == *o
+	if s == nil || o == nil {
+		return s == o
+	}
+	return bytes.Equal(s.encoding(), o.encoding())
 }
 
+// HashSSZ returns the root of the committee. It hashes the public keys straight from the committee and
+// never builds its encoding, so callers wanting the root should not call EncodeSSZ first. The root is
+// cached on the committee until its keys change.
 func (s *SyncCommittee) HashSSZ() ([32]byte, error) {
-	syncCommitteeLayer := make([]byte, 512*32)
-	for i := 0; i < 512; i++ {
-		root, err := merkle_tree.BytesRoot(s[i*48 : (i*48)+48])
-		if err != nil {
+	return s.HashSSZWithContext(context.Background())
+}
+
+// HashSSZWithContext is HashSSZ, except that the computation stops with ctx.Err() if ctx is done before it
+// completes.
+func (s *SyncCommittee) HashSSZWithContext(ctx context.Context) ([32]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.rootValid {
+		return s.root, nil
+	}
+	if s.pubKeysTree == nil {
+		if err := s.buildPubKeysTree(ctx); err != nil {
 			return [32]byte{}, err
 		}
-		copy(syncCommitteeLayer[i*32:], root[:])
 	}
-	return merkle_tree.HashTreeRoot(syncCommitteeLayer, s[syncCommitteeSize-48:])
+	pubKeysRoot, err := s.pubKeysTree.Root()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	aggregatePublicKeyRoot := merkle_tree.PublicKeyRoot(s.AggregatePublicKey())
+	s.root, s.rootValid = utils.Sha256(pubKeysRoot[:], aggregatePublicKeyRoot[:]), true
+	return s.root, nil
+}
+
+// buildPubKeysTree hashes every member public key into the leaves of a new tree of the members.
+func (s *SyncCommittee) buildPubKeysTree(ctx context.Context) error {
+	members := s.syncCommitteeMembers()
+	leaves := make([][32]byte, members)
+	if err := s.computePubKeysLayer(ctx, leaves); err != nil {
+		return err
+	}
+	tree := merkle_tree.NewCachedTree(uint64(members))
+	if err := tree.Reset(leaves); err != nil {
+		return err
+	}
+	s.pubKeysLeaves, s.pubKeysTree = leaves, tree
+	return nil
+}
+
+// pubKeysLayerBatchSize is the number of public keys hashed between two checks of the context.
+const pubKeysLayerBatchSize = 64
+
+// computePubKeysLayer writes the roots of the committee public keys to leaves, splitting the work
+// across at most runtime.NumCPU() goroutines. Each goroutine owns a disjoint range of leaves
+// and gives up as soon as ctx is done.
+func (s *SyncCommittee) computePubKeysLayer(ctx context.Context, leaves [][32]byte) error {
+	members := s.syncCommitteeMembers()
+	workers := runtime.NumCPU()
+	batchSize := (members + workers - 1) / workers
+	g, ctx := errgroup.WithContext(ctx)
+	for from := 0; from < members; from += batchSize {
+		from, to := from, from+batchSize
+		if to > members {
+			to = members
+		}
+		g.Go(func() error {
+			chunksCount := to - from
+			if chunksCount > pubKeysLayerBatchSize {
+				chunksCount = pubKeysLayerBatchSize
+			}
+			// A 48 bytes public key is merkleized as two chunks, the second one zero padded. Only
+			// the first 48 bytes of each pair are ever written, so the buffer is reused across batches.
+			chunks := make([][32]byte, chunksCount*2)
+			for batchFrom := from; batchFrom < to; batchFrom += pubKeysLayerBatchSize {
+				if err := ctx.Err(); err != nil {
+					return err
+				}
+				batchTo := batchFrom + pubKeysLayerBatchSize
+				if batchTo > to {
+					batchTo = to
+				}
+				if err := s.computePubKeysLayerRange(leaves, chunks, batchFrom, batchTo); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	}
+	return g.Wait()
+}
+
+func (s *SyncCommittee) computePubKeysLayerRange(leaves, chunks [][32]byte, from, to int) error {
+	for i := from; i < to; i++ {
+		key := s.encoding()[i*48 : (i+1)*48]
+		copy(chunks[2*(i-from)][:], key[:length.Hash])
+		copy(chunks[2*(i-from)+1][:], key[length.Hash:])
+	}
+	return gohashtree.Hash(leaves[from:to], chunks[:2*(to-from)])
 }
 
 func (s *SyncCommittee) Static() bool {
@@ -112,6 +355,10 @@ func (s *SyncCommittee) UnmarshalJSON(input []byte) error {
 	if err = json.Unmarshal(input, &tmp); err != nil {
 		return err
 	}
+	// The committee takes the size of the decoded one.
+	s.mu.Lock()
+	s.keys = make([]byte, (len(tmp.Committee)+1)*48)
+	s.mu.Unlock()
 	s.SetAggregatePublicKey(tmp.AggregatePublicKey)
 	s.SetCommittee(tmp.Committee)
 	return nil
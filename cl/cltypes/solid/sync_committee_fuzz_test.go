@@ -0,0 +1,33 @@
+//go:build !nofuzz
+
+package solid
+
+import (
+	"bytes"
+	"testing"
+)
+
+func FuzzSyncCommittee(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(make([]byte, syncCommitteeSize))
+	f.Fuzz(func(t *testing.T, in []byte) {
+		committee := &SyncCommittee{}
+		if err := committee.DecodeSSZ(in, 0); err != nil {
+			return
+		}
+		encoded, err := committee.EncodeSSZ(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(encoded, in) {
+			t.Fatal("re-encoding differs from the input")
+		}
+		decoded := &SyncCommittee{}
+		if err := decoded.DecodeSSZ(encoded, 0); err != nil {
+			t.Fatal(err)
+		}
+		if !decoded.Equal(committee) {
+			t.Fatal("round trip differs")
+		}
+	})
+}
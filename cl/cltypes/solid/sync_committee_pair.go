@@ -0,0 +1,102 @@
+package solid
+
+import (
+	"fmt"
+
+	"github.com/ledgerwatch/erigon-lib/types/clonable"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
+	"github.com/ledgerwatch/erigon/cl/merkle_tree"
+)
+
+// SyncCommitteePair is the current and next sync committees of a beacon state, encoded back to back
+// as they appear in the state and rooted as two container leaves.
+type SyncCommitteePair struct {
+	Current *SyncCommittee
+	Next    *SyncCommittee
+}
+
+var (
+	_ ssz.EncodableSSZ = (*SyncCommitteePair)(nil)
+	_ ssz.HashableSSZ  = (*SyncCommitteePair)(nil)
+)
+
+func NewSyncCommitteePair(current, next *SyncCommittee) *SyncCommitteePair {
+	return &SyncCommitteePair{Current: current, Next: next}
+}
+
+// EncodingSizeSSZ is the size of two committees of the preset of the current one, or of the mainnet
+// preset if it is missing.
+func (p *SyncCommitteePair) EncodingSizeSSZ() int {
+	if p.Current == nil {
+		return 2 * (&SyncCommittee{}).EncodingSizeSSZ()
+	}
+	return 2 * p.Current.EncodingSizeSSZ()
+}
+
+func (p *SyncCommitteePair) EncodeSSZ(dst []byte) ([]byte, error) {
+	if p.Current == nil || p.Next == nil {
+		return nil, fmt.Errorf("[SyncCommitteePair] err: missing sync committee")
+	}
+	dst, err := p.Current.EncodeSSZ(dst)
+	if err != nil {
+		return nil, err
+	}
+	return p.Next.EncodeSSZ(dst)
+}
+
+func (p *SyncCommitteePair) DecodeSSZ(buf []byte, version int) error {
+	if len(buf) < p.EncodingSizeSSZ() {
+		return fmt.Errorf("[SyncCommitteePair] err: %w", ssz.ErrLowBufferSize)
+	}
+	if len(buf) > p.EncodingSizeSSZ() {
+		return fmt.Errorf("[SyncCommitteePair] err: %w: expected %d bytes, got %d", ssz.ErrBufferTooLong, p.EncodingSizeSSZ(), len(buf))
+	}
+	if p.Current == nil {
+		p.Current = &SyncCommittee{}
+	}
+	if p.Next == nil {
+		p.Next = p.Current.Clone().(*SyncCommittee)
+	}
+	// Both committees are of the preset of the current one.
+	committeeSize := p.EncodingSizeSSZ() / 2
+	if err := p.Current.DecodeSSZ(buf[:committeeSize], version); err != nil {
+		return fmt.Errorf("[SyncCommitteePair] current: %w", err)
+	}
+	if err := p.Next.DecodeSSZ(buf[committeeSize:], version); err != nil {
+		return fmt.Errorf("[SyncCommitteePair] next: %w", err)
+	}
+	return nil
+}
+
+func (p *SyncCommitteePair) HashSSZ() ([32]byte, error) {
+	if p.Current == nil || p.Next == nil {
+		return [32]byte{}, fmt.Errorf("[SyncCommitteePair] err: missing sync committee")
+	}
+	return merkle_tree.HashTreeRoot(p.Current, p.Next)
+}
+
+func (p *SyncCommitteePair) Clone() clonable.Clonable {
+	return &SyncCommitteePair{}
+}
+
+func (p *SyncCommitteePair) Copy() *SyncCommitteePair {
+	copied := &SyncCommitteePair{}
+	if p.Current != nil {
+		copied.Current = p.Current.Copy()
+	}
+	if p.Next != nil {
+		copied.Next = p.Next.Copy()
+	}
+	return copied
+}
+
+func (p *SyncCommitteePair) Equal(o *SyncCommitteePair) bool {
+	if p == nil || o == nil {
+		return p == o
+	}
+	return p.Current.Equal(o.Current) && p.Next.Equal(o.Next)
+}
+
+func (p *SyncCommitteePair) Static() bool {
+	return true
+}
@@ -0,0 +1,74 @@
+package solid
+
+import (
+	"testing"
+
+	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cl/utils"
+)
+
+func testSyncCommitteeWithTag(tag, aggregate byte) *SyncCommittee {
+	committee := make([]libcommon.Bytes48, 512)
+	for i := range committee {
+		committee[i] = libcommon.Bytes48{byte(i), byte(i >> 8), tag}
+	}
+	return NewSyncCommitteeFromParameters(committee, libcommon.Bytes48{aggregate})
+}
+
+func TestSyncCommitteePair(t *testing.T) {
+	current, next := testSyncCommitteeWithTag(1, 0xaa), testSyncCommitteeWithTag(2, 0xbb)
+	pair := NewSyncCommitteePair(current, next)
+
+	encoded, err := pair.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Len(t, encoded, pair.EncodingSizeSSZ())
+	require.Equal(t, current.keys[:], encoded[:syncCommitteeSize])
+	require.Equal(t, next.keys[:], encoded[syncCommitteeSize:])
+
+	decoded := &SyncCommitteePair{}
+	require.NoError(t, decoded.DecodeSSZ(encoded, 0))
+	require.True(t, pair.Equal(decoded))
+	require.True(t, pair.Equal(pair.Copy()))
+
+	// The pair is rooted as the two committees side by side in a container, as in the beacon state.
+	root, err := pair.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, libcommon.HexToHash("e17c41044ee14d38932c7a5bba9c305d449a298624f989cb8dcf3730b405018c"), libcommon.Hash(root))
+	currentRoot, err := current.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, libcommon.HexToHash("c046b6a5e6b33c80e00e3f7d65d2ed33a7c3a8af17faae25c25261afbc6eca39"), libcommon.Hash(currentRoot))
+	nextRoot, err := next.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, utils.Sha256(currentRoot[:], nextRoot[:]), root)
+
+	zeroRoot, err := NewSyncCommitteePair(&SyncCommittee{}, &SyncCommittee{}).HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, libcommon.HexToHash("ef13652897e342429ea5d922f68f48131b434d2591758e3dfd18be4502e19869"), libcommon.Hash(zeroRoot))
+}
+
+func TestSyncCommitteePairErrors(t *testing.T) {
+	pair := &SyncCommitteePair{}
+	require.ErrorIs(t, pair.DecodeSSZ(make([]byte, syncCommitteeSize), 0), ssz.ErrLowBufferSize)
+	require.ErrorIs(t, pair.DecodeSSZ(make([]byte, 2*syncCommitteeSize+1), 0), ssz.ErrBufferTooLong)
+	_, err := pair.EncodeSSZ(nil)
+	require.Error(t, err)
+	_, err = NewSyncCommitteePair(&SyncCommittee{}, nil).HashSSZ()
+	require.Error(t, err)
+	require.False(t, pair.Equal(NewSyncCommitteePair(&SyncCommittee{}, &SyncCommittee{})))
+}
+
+func TestSyncCommitteePairMinimalPreset(t *testing.T) {
+	current := NewSyncCommittee(32)
+	current.SetPubKey(31, libcommon.Bytes48{1})
+	decoded := NewSyncCommitteePair(current.Clone().(*SyncCommittee), nil)
+	require.Equal(t, 2*33*48, decoded.EncodingSizeSSZ())
+
+	encoded, err := NewSyncCommitteePair(current, current.Copy()).EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.NoError(t, decoded.DecodeSSZ(encoded, 0))
+	// The next committee is allocated with the size of the current one.
+	require.True(t, current.Equal(decoded.Next))
+}
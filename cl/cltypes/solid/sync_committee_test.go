@@ -1,10 +1,16 @@
 package solid
 
 import (
+	"context"
+	_ "embed"
+	"sync"
 	"testing"
 
 	"github.com/ledgerwatch/erigon-lib/common"
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
+	"github.com/ledgerwatch/erigon/cl/merkle_tree"
+	"github.com/ledgerwatch/erigon/cl/utils"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -49,7 +55,7 @@ func TestSyncCommittee(t *testing.T) {
 	decodedSyncCommittee := &SyncCommittee{}
 	err = decodedSyncCommittee.DecodeSSZ(encodedData, encodingSize)
 	assert.NoError(t, err)
-	assert.Equal(t, syncCommittee, decodedSyncCommittee)
+	assert.True(t, syncCommittee.Equal(decodedSyncCommittee))
 
 	// Test Clone
 	clone := syncCommittee.Clone().(*SyncCommittee)
@@ -63,6 +69,7 @@ func TestSyncCommittee(t *testing.T) {
 	otherSyncCommittee := &SyncCommittee{}
 	assert.False(t, syncCommittee.Equal(otherSyncCommittee))
 	assert.True(t, syncCommittee.Equal(syncCommittee))
+	assert.True(t, syncCommittee.Equal(syncCommittee.Copy()))
 
 	// Test HashSSZ
 	expectedRoot := common.HexToHash("28628f3f10fa1070f2a42aeeeae792cd6ded1ef81030104e765e1498a1cfcfbd") // Example expected root
@@ -89,3 +96,428 @@ func TestSyncCommitteeJson(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, syncCommittee, decodedSyncCommittee)
 }
+
+func TestSyncCommitteeHashSSZCache(t *testing.T) {
+	committee := make([]libcommon.Bytes48, 512)
+	for i := range committee {
+		committee[i][0] = byte(i)
+	}
+	syncCommittee := NewSyncCommitteeFromParameters(committee, [48]byte{1, 2, 3})
+
+	root, err := syncCommittee.HashSSZ()
+	assert.NoError(t, err)
+	assert.Equal(t, common.HexToHash("e7077a4d1a245aeb13ba2e1ee61116f8946be69dd386c19e5d7d8d3bcc4b87c6"), common.Hash(root))
+	cachedRoot, err := syncCommittee.HashSSZ()
+	assert.NoError(t, err)
+	assert.Equal(t, root, cachedRoot)
+
+	// Mutating the aggregate key must not return the stale root.
+	syncCommittee.SetAggregatePublicKey([48]byte{4, 5, 6})
+	mutatedRoot, err := syncCommittee.HashSSZ()
+	assert.NoError(t, err)
+	assert.Equal(t, common.HexToHash("28628f3f10fa1070f2a42aeeeae792cd6ded1ef81030104e765e1498a1cfcfbd"), common.Hash(mutatedRoot))
+
+	// Copies carry the cached root, but do not share it.
+	copied := syncCommittee.Copy()
+	assert.True(t, copied.rootValid)
+	copied.SetAggregatePublicKey([48]byte{1, 2, 3})
+	copiedRoot, err := copied.HashSSZ()
+	assert.NoError(t, err)
+	assert.Equal(t, root, copiedRoot)
+	stillMutatedRoot, err := syncCommittee.HashSSZ()
+	assert.NoError(t, err)
+	assert.Equal(t, mutatedRoot, stillMutatedRoot)
+
+	// Decoding replaces the keys and drops the cached root.
+	encoded, err := copied.EncodeSSZ(nil)
+	assert.NoError(t, err)
+	assert.NoError(t, syncCommittee.DecodeSSZ(encoded, 0))
+	assert.False(t, syncCommittee.rootValid)
+	decodedRoot, err := syncCommittee.HashSSZ()
+	assert.NoError(t, err)
+	assert.Equal(t, root, decodedRoot)
+}
+
+// BenchmarkSyncCommitteeHashSSZ reports the memory used to root a committee. Uncached, on a freshly decoded
+// committee, it is the 16KB of key roots and the layers of their tree, which the committee keeps, and a 4KB
+// chunk buffer per worker: the 24624 bytes encoding is never built.
+func BenchmarkSyncCommitteeHashSSZ(b *testing.B) {
+	syncCommittee := NewSyncCommitteeFromParameters(make([]libcommon.Bytes48, 512), [48]byte{1, 2, 3})
+	encoded, err := syncCommittee.EncodeSSZ(nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	b.Run("uncached", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := syncCommittee.DecodeSSZ(encoded, 0); err != nil {
+				b.Fatal(err)
+			}
+			syncCommittee.HashSSZ()
+		}
+	})
+	b.Run("cached", func(b *testing.B) {
+		syncCommittee.HashSSZ()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			syncCommittee.HashSSZ()
+		}
+	})
+}
+
+func BenchmarkSyncCommitteeSetPubKey(b *testing.B) {
+	committee := make([]libcommon.Bytes48, 512)
+	for i := range committee {
+		committee[i][0] = byte(i)
+	}
+	syncCommittee := NewSyncCommitteeFromParameters(committee, [48]byte{1})
+	syncCommittee.HashSSZ()
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		syncCommittee.SetPubKey(i%512, libcommon.Bytes48{byte(i)})
+		syncCommittee.HashSSZ()
+	}
+}
+
+func BenchmarkSyncCommitteeDecodeSSZ(b *testing.B) {
+	encoded := make([]byte, syncCommitteeSize)
+	for i := range encoded {
+		encoded[i] = byte(i * 7)
+	}
+	decoded := &SyncCommittee{}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := decoded.DecodeSSZ(encoded, 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func serialPubKeysLayer(s *SyncCommittee) ([][32]byte, error) {
+	leaves := make([][32]byte, 512)
+	for i := range leaves {
+		leaves[i] = merkle_tree.PublicKeyRoot(common.Bytes48(s.keys[i*48 : (i*48)+48]))
+	}
+	return leaves, nil
+}
+
+func TestSyncCommitteePubKeysLayer(t *testing.T) {
+	syncCommittee := NewSyncCommittee(512)
+	for i := range syncCommittee.keys {
+		syncCommittee.keys[i] = byte(i * 7)
+	}
+	expected, err := serialPubKeysLayer(syncCommittee)
+	assert.NoError(t, err)
+	leaves := make([][32]byte, 512)
+	assert.NoError(t, syncCommittee.computePubKeysLayer(context.Background(), leaves))
+	assert.Equal(t, expected, leaves)
+}
+
+func BenchmarkSyncCommitteePubKeysLayer(b *testing.B) {
+	syncCommittee := NewSyncCommittee(512)
+	for i := range syncCommittee.keys {
+		syncCommittee.keys[i] = byte(i * 7)
+	}
+	b.Run("serial", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			serialPubKeysLayer(syncCommittee)
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		leaves := make([][32]byte, 512)
+		for i := 0; i < b.N; i++ {
+			syncCommittee.computePubKeysLayer(context.Background(), leaves)
+		}
+	})
+}
+
+func TestSyncCommitteeDecodeSSZLength(t *testing.T) {
+	s := &SyncCommittee{}
+	assert.ErrorIs(t, s.DecodeSSZ(make([]byte, syncCommitteeSize-1), 0), ssz.ErrLowBufferSize)
+	assert.NoError(t, s.DecodeSSZ(make([]byte, syncCommitteeSize), 0))
+	err := s.DecodeSSZ(make([]byte, syncCommitteeSize+1), 0)
+	assert.ErrorIs(t, err, ssz.ErrBufferTooLong)
+	assert.ErrorContains(t, err, "expected 24624 bytes, got 24625")
+}
+
+func TestSyncCommitteeDecodeSSZPresets(t *testing.T) {
+	for _, test := range []struct {
+		name          string
+		members       int
+		size          int
+		aggregateFrom int
+	}{
+		{"mainnet", 512, 24624, 24576},
+		{"minimal", 32, 1584, 1536},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			buf := make([]byte, test.size)
+			for i := range buf {
+				buf[i] = byte(i * 7)
+			}
+			s := NewSyncCommittee(test.members)
+			assert.NoError(t, s.DecodeSSZ(buf, 0))
+			committee := s.GetCommittee()
+			assert.Len(t, committee, test.members)
+			assert.Equal(t, buf[test.aggregateFrom-48:test.aggregateFrom], committee[len(committee)-1][:])
+			aggregatePublicKey := s.AggregatePublicKey()
+			assert.Equal(t, buf[test.aggregateFrom:], aggregatePublicKey[:])
+			encoded, err := s.EncodeSSZ(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, buf, encoded)
+
+			assert.ErrorIs(t, s.DecodeSSZ(buf[:test.size-1], 0), ssz.ErrLowBufferSize)
+			assert.ErrorIs(t, s.DecodeSSZ(append(buf, 0), 0), ssz.ErrBufferTooLong)
+
+			// The pair decodes committees of the preset of its current one.
+			pair := &SyncCommitteePair{Current: s.Clone().(*SyncCommittee)}
+			pairBuf := append(append([]byte{}, buf...), buf...)
+			pairBuf[test.size] ^= 0xff
+			assert.NoError(t, pair.DecodeSSZ(pairBuf, 0))
+			assert.True(t, s.Equal(pair.Current))
+			assert.False(t, s.Equal(pair.Next))
+			encoded, err = pair.EncodeSSZ(nil)
+			assert.NoError(t, err)
+			assert.Equal(t, pairBuf, encoded)
+		})
+	}
+}
+
+func TestSyncCommitteeSetPubKey(t *testing.T) {
+	committee := make([]libcommon.Bytes48, 512)
+	for i := range committee {
+		committee[i] = libcommon.Bytes48{byte(i), byte(i >> 8)}
+	}
+	syncCommittee := NewSyncCommitteeFromParameters(committee, [48]byte{1})
+	// Build the tree of the members before the update.
+	_, err := syncCommittee.HashSSZ()
+	assert.NoError(t, err)
+	tree := syncCommittee.pubKeysTree
+
+	for _, index := range []int{0, 200, 511} {
+		committee[index] = libcommon.Bytes48{0xff, byte(index)}
+		assert.NoError(t, syncCommittee.SetPubKey(index, committee[index]))
+	}
+	// Only the paths of the replaced keys are rehashed, in the tree built before.
+	assert.Same(t, tree, syncCommittee.pubKeysTree)
+	root, err := syncCommittee.HashSSZ()
+	assert.NoError(t, err)
+	assert.Equal(t, common.HexToHash("f49dfca7522ad957b9c7c9332452c1fc9b95c68c7b8952aa6c3cd1004f268940"), common.Hash(root))
+	assert.Equal(t, committee, syncCommittee.GetCommittee())
+
+	// The aggregate public key is not a member.
+	assert.Error(t, syncCommittee.SetPubKey(512, libcommon.Bytes48{2}))
+	assert.Error(t, syncCommittee.SetPubKey(-1, libcommon.Bytes48{2}))
+	assert.Equal(t, libcommon.Bytes48{1}, syncCommittee.AggregatePublicKey())
+}
+
+func TestSyncCommitteeConcurrentMutations(t *testing.T) {
+	committee := make([]libcommon.Bytes48, 512)
+	syncCommittee := NewSyncCommitteeFromParameters(committee, [48]byte{1})
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := range committee {
+			committee[i] = libcommon.Bytes48{byte(i), byte(i >> 8), 0xff}
+			assert.NoError(t, syncCommittee.SetPubKey(i, committee[i]))
+		}
+		syncCommittee.SetAggregatePublicKey([48]byte{2})
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 16; i++ {
+			_, err := syncCommittee.HashSSZ()
+			assert.NoError(t, err)
+		}
+	}()
+	wg.Wait()
+
+	// No root computed while the keys were being written is left cached.
+	root, err := syncCommittee.HashSSZ()
+	assert.NoError(t, err)
+	expected, err := NewSyncCommitteeFromParameters(committee, [48]byte{2}).HashSSZ()
+	assert.NoError(t, err)
+	assert.Equal(t, expected, root)
+}
+
+func TestSyncCommitteeEqual(t *testing.T) {
+	committee := make([]libcommon.Bytes48, 512)
+	for i := range committee {
+		committee[i][0] = byte(i)
+	}
+	syncCommittee := NewSyncCommitteeFromParameters(committee, [48]byte{1})
+
+	otherPubKey := syncCommittee.Copy()
+	committee[511][47] = 1
+	otherPubKey.SetCommittee(committee)
+	assert.False(t, syncCommittee.Equal(otherPubKey))
+	assert.False(t, otherPubKey.Equal(syncCommittee))
+
+	otherAggregate := syncCommittee.Copy()
+	otherAggregate.SetAggregatePublicKey([48]byte{2})
+	assert.False(t, syncCommittee.Equal(otherAggregate))
+
+	assert.True(t, (*SyncCommittee)(nil).Equal(nil))
+	assert.False(t, (*SyncCommittee)(nil).Equal(syncCommittee))
+	assert.False(t, syncCommittee.Equal(nil))
+}
+
+func TestSyncCommitteeParticipatingPubkeys(t *testing.T) {
+	committee := make([]libcommon.Bytes48, 512)
+	for i := range committee {
+		committee[i][0] = byte(i)
+		committee[i][1] = byte(i >> 8)
+	}
+	syncCommittee := NewSyncCommitteeFromParameters(committee, [48]byte{1})
+
+	allSet := make([]byte, 64)
+	alternating := make([]byte, 64)
+	for i := range allSet {
+		allSet[i] = 0xff
+		alternating[i] = 0x55
+	}
+	var even []libcommon.Bytes48
+	for i := 0; i < 512; i += 2 {
+		even = append(even, committee[i])
+	}
+
+	tests := []struct {
+		name     string
+		bits     []byte
+		expected []libcommon.Bytes48
+	}{
+		{name: "all set", bits: allSet, expected: committee},
+		{name: "none set", bits: make([]byte, 64), expected: nil},
+		{name: "alternating", bits: alternating, expected: even},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			participants, err := syncCommittee.ParticipatingPubkeys(tt.bits)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, participants)
+		})
+	}
+
+	_, err := syncCommittee.ParticipatingPubkeys(make([]byte, 63))
+	assert.Error(t, err)
+	_, err = syncCommittee.ParticipatingPubkeys(make([]byte, 65))
+	assert.Error(t, err)
+}
+
+// testSyncCommittee is the current sync committee of a mainnet preset capella state, its aggregate
+// public key was computed by the consensus spec.
+//
+//go:embed testdata/sync_committee.ssz_snappy
+var testSyncCommittee []byte
+
+func TestSyncCommitteeAggregatePubkeys(t *testing.T) {
+	syncCommittee := &SyncCommittee{}
+	assert.NoError(t, utils.DecodeSSZSnappy(syncCommittee, testSyncCommittee, 0))
+	expected := libcommon.Bytes48(libcommon.Hex2Bytes("85db3996834db5ea268491619c1a1122a67ed664d1a55450f2c1d872c2aaaff7e57d3a9d0acfc8076a7a5b2a9435d184"))
+	assert.Equal(t, expected, syncCommittee.AggregatePublicKey())
+
+	allSet := make([]byte, 64)
+	for i := range allSet {
+		allSet[i] = 0xff
+	}
+	aggregate, err := syncCommittee.AggregatePubkeys(allSet)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, aggregate)
+
+	oneSet := make([]byte, 64)
+	oneSet[0] = 0x02
+	aggregate, err = syncCommittee.AggregatePubkeys(oneSet)
+	assert.NoError(t, err)
+	assert.Equal(t, syncCommittee.GetCommittee()[1], aggregate)
+
+	_, err = syncCommittee.AggregatePubkeys(make([]byte, 64))
+	assert.Error(t, err)
+	_, err = syncCommittee.AggregatePubkeys(allSet[:63])
+	assert.Error(t, err)
+}
+
+func TestSyncCommitteeHashSSZWithContext(t *testing.T) {
+	syncCommittee := NewSyncCommittee(512)
+	for i := range syncCommittee.keys {
+		syncCommittee.keys[i] = byte(i * 13)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := syncCommittee.HashSSZWithContext(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+	// An aborted computation is not cached.
+	assert.False(t, syncCommittee.rootValid)
+
+	expected := common.HexToHash("ec1d0b14b9bb3857e424ff89cb39e5fa19fb61dab5368f5f39aac9163f3dcd85")
+	root, err := syncCommittee.HashSSZWithContext(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, expected, common.Hash(root))
+	// Once cached, the root is returned whatever the context.
+	root, err = syncCommittee.HashSSZWithContext(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, expected, common.Hash(root))
+}
+
+func TestSyncCommitteeMinimalPreset(t *testing.T) {
+	committee := make([]libcommon.Bytes48, 32)
+	for i := range committee {
+		for j := range committee[i] {
+			committee[i][j] = byte(i + 1)
+		}
+	}
+	var aggregatePublicKey libcommon.Bytes48
+	for i := range aggregatePublicKey {
+		aggregatePublicKey[i] = 0xaa
+	}
+	syncCommittee := NewSyncCommittee(32)
+	syncCommittee.SetAggregatePublicKey(aggregatePublicKey)
+	syncCommittee.SetCommittee(committee)
+	assert.Equal(t, committee, syncCommittee.GetCommittee())
+	assert.Equal(t, aggregatePublicKey, syncCommittee.AggregatePublicKey())
+
+	assert.Equal(t, 33*48, syncCommittee.EncodingSizeSSZ())
+	encoded, err := syncCommittee.EncodeSSZ(nil)
+	assert.NoError(t, err)
+	assert.Len(t, encoded, 33*48)
+	assert.Equal(t, aggregatePublicKey[:], encoded[32*48:])
+
+	root, err := syncCommittee.HashSSZ()
+	assert.NoError(t, err)
+	assert.Equal(t, common.HexToHash("df795c7f3b01b00a31c355e08eed682bb23f366457b26a033c119e7c40f8132c"), common.Hash(root))
+
+	participants, err := syncCommittee.ParticipatingPubkeys([]byte{0x01, 0, 0, 0x80})
+	assert.NoError(t, err)
+	assert.Equal(t, []libcommon.Bytes48{committee[0], committee[31]}, participants)
+	_, err = syncCommittee.ParticipatingPubkeys(make([]byte, 64))
+	assert.Error(t, err)
+
+	// Copies and clones keep the size.
+	assert.True(t, syncCommittee.Equal(syncCommittee.Copy()))
+	assert.Equal(t, 33*48, syncCommittee.Clone().(*SyncCommittee).EncodingSizeSSZ())
+	assert.False(t, syncCommittee.Equal(&SyncCommittee{}))
+}
+
+func TestSyncCommitteeSizes(t *testing.T) {
+	// A committee is rooted as a vector of its members, padded to the next power of two.
+	for _, members := range []int{1, 24, 32, 1024} {
+		committee := make([]libcommon.Bytes48, members)
+		leaves := make([][32]byte, members)
+		for i := range committee {
+			committee[i] = libcommon.Bytes48{byte(i), byte(i >> 8), 1}
+			leaves[i] = merkle_tree.PublicKeyRoot(committee[i])
+		}
+		syncCommittee := NewSyncCommitteeFromParameters(committee, [48]byte{2})
+		assert.Equal(t, (members+1)*48, syncCommittee.EncodingSizeSSZ())
+		assert.Equal(t, committee, syncCommittee.GetCommittee())
+
+		pubKeysRoot, err := merkle_tree.VectorRoot(leaves, uint64(members))
+		assert.NoError(t, err)
+		aggregatePublicKeyRoot := merkle_tree.PublicKeyRoot([48]byte{2})
+		root, err := syncCommittee.HashSSZ()
+		assert.NoError(t, err)
+		assert.Equal(t, utils.Sha256(pubKeysRoot[:], aggregatePublicKeyRoot[:]), root, members)
+	}
+}
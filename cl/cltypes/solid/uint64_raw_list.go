@@ -8,6 +8,7 @@ import (
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/common/length"
 	"github.com/ledgerwatch/erigon-lib/types/clonable"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
 	"github.com/ledgerwatch/erigon/cl/merkle_tree"
 	"github.com/ledgerwatch/erigon/cl/utils"
 )
@@ -81,6 +82,12 @@ func (arr *RawUint64List) EncodeSSZ(buf []byte) (dst []byte, err error) {
 }
 
 func (arr *RawUint64List) DecodeSSZ(buf []byte, _ int) error {
+	if len(buf)%8 > 0 {
+		return ssz.ErrBadDynamicLength
+	}
+	if err := ssz.CheckListLimit(uint64(len(buf)/8), uint64(arr.c)); err != nil {
+		return err
+	}
 	arr.cachedHash = libcommon.Hash{}
 	arr.u = make([]uint64, len(buf)/8)
 	for i := range arr.u {
@@ -182,8 +182,7 @@ func (arr *byteBasedUint64Slice) HashListSSZ() ([32]byte, error) {
 			return [32]byte{}, err
 		}
 	}
-	lengthRoot := merkle_tree.Uint64Root(uint64(arr.l))
-	return utils.Sha256(baseRoot[:], lengthRoot[:]), nil
+	return merkle_tree.MixInLength(baseRoot, uint64(arr.l)), nil
 }
 
 // HashVectorSSZ computes the SSZ hash of the slice as a vector. It returns the hash and any error encountered.
@@ -243,6 +242,9 @@ func (arr *byteBasedUint64Slice) DecodeSSZ(buf []byte, _ int) error {
 	if len(buf)%8 > 0 {
 		return ssz.ErrBadDynamicLength
 	}
+	if err := ssz.CheckListLimit(uint64(len(buf)/8), uint64(arr.c)); err != nil {
+		return err
+	}
 	arr.l = len(buf) / 8
 	bufferLength := length.Hash*((arr.l-1)/4) + length.Hash
 	arr.u = make([]byte, bufferLength)
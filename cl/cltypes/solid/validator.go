@@ -60,7 +60,30 @@ func (v Validator) CopyTo(dst Validator) {
 	copy(dst[:], v[:])
 }
 
+// zeroValidatorRoot is the root of a validator with all its fields set to zero.
+var zeroValidatorRoot, _ = NewValidator().hashSSZ()
+
+// IsZero reports whether all the fields of the validator are zero.
+func (v Validator) IsZero() bool {
+	if len(v) != validatorSize {
+		return false
+	}
+	for _, b := range v {
+		if b != 0 {
+			return false
+		}
+	}
+	return true
+}
+
 func (v Validator) HashSSZ() ([32]byte, error) {
+	if v.IsZero() {
+		return zeroValidatorRoot, nil
+	}
+	return v.hashSSZ()
+}
+
+func (v Validator) hashSSZ() ([32]byte, error) {
 	hashBuffer := make([]byte, 8*32)
 	if err := v.CopyHashBufferTo(hashBuffer); err != nil {
 		return [32]byte{}, err
@@ -142,6 +142,10 @@ func (v *ValidatorSet) DecodeSSZ(buf []byte, _ int) error {
 	}
 	v.expandBuffer(len(buf) / validatorSize)
 	copy(v.buffer, buf)
+	// The cached group roots belong to the previous content.
+	for i := range v.treeCacheBuffer {
+		v.treeCacheBuffer[i] = 0
+	}
 	v.l = len(buf) / validatorSize
 	v.phase0Data = make([]Phase0Data, v.l)
 	v.attesterBits = make([]byte, v.l)
@@ -249,6 +253,7 @@ func (v *ValidatorSet) Set(idx int, val Validator) {
 	if idx >= v.l {
 		panic("ValidatorSet -- Set: out of bounds")
 	}
+	v.zeroTreeHash(idx)
 	copy(v.buffer[idx*validatorSize:(idx*validatorSize)+validatorSize], val)
 }
 
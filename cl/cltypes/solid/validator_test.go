@@ -2,9 +2,11 @@ package solid
 
 import (
 	"encoding/binary"
+	"math/rand"
 	"testing"
 
 	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon/cl/merkle_tree"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -76,6 +78,70 @@ func TestValidatorSetTest(t *testing.T) {
 	require.Equal(t, firstHash, secondHash)
 }
 
+// fullValidatorSetRoot roots the validators from scratch, without the tree cache of the set.
+func fullValidatorSetRoot(t *testing.T, vset *ValidatorSet) [32]byte {
+	leaves := make([][32]byte, 0, vset.Length())
+	vset.Range(func(_ int, validator Validator, _ int) bool {
+		root, err := validator.HashSSZ()
+		require.NoError(t, err)
+		leaves = append(leaves, root)
+		return true
+	})
+	root, err := merkle_tree.ListRoot(leaves, uint64(vset.Cap()))
+	require.NoError(t, err)
+	return root
+}
+
+func TestValidatorSetIncrementalHashSSZ(t *testing.T) {
+	rng := rand.New(rand.NewSource(42))
+	randomValidator := func() Validator {
+		var pk [48]byte
+		var wk [32]byte
+		rng.Read(pk[:])
+		rng.Read(wk[:])
+		return NewValidatorFromParameters(pk, wk, rng.Uint64(), rng.Intn(2) == 1, rng.Uint64(), rng.Uint64(), rng.Uint64(), rng.Uint64())
+	}
+
+	vset := NewValidatorSet(1 << 40)
+	for i := 0; i < 100; i++ {
+		vset.Append(randomValidator())
+	}
+	for round := 0; round < 50; round++ {
+		for j := 0; j < 1+rng.Intn(5); j++ {
+			idx := rng.Intn(vset.Length())
+			switch rng.Intn(5) {
+			case 0:
+				vset.Set(idx, randomValidator())
+			case 1:
+				vset.SetEffectiveBalanceForValidatorAtIndex(idx, rng.Uint64())
+			case 2:
+				vset.SetExitEpochForValidatorAtIndex(idx, rng.Uint64())
+			case 3:
+				vset.SetValidatorSlashed(idx, !vset.Get(idx).Slashed())
+			case 4:
+				vset.Append(randomValidator())
+			}
+		}
+		root, err := vset.HashSSZ()
+		require.NoError(t, err)
+		require.Equal(t, fullValidatorSetRoot(t, vset), root, "round %d", round)
+	}
+
+	// Decoding into a set with cached roots must not reuse them.
+	other := NewValidatorSet(1 << 40)
+	for i := 0; i < vset.Length(); i++ {
+		other.Append(randomValidator())
+	}
+	_, err := other.HashSSZ()
+	require.NoError(t, err)
+	encoded, err := vset.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.NoError(t, other.DecodeSSZ(encoded, 0))
+	root, err := other.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, fullValidatorSetRoot(t, vset), root)
+}
+
 func TestMarshalUnmarshalJson(t *testing.T) {
 	validator := NewValidatorFromParameters(
 		[48]byte{1, 2, 3}, [32]byte{4, 5, 6}, 7, true, 8, 9, 10, 11,
@@ -87,3 +153,42 @@ func TestMarshalUnmarshalJson(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, validator, decoded)
 }
+
+func TestValidatorLifecycle(t *testing.T) {
+	validator := NewValidatorFromParameters([48]byte{1}, common.Hash{2}, 32000000000, false, 5, 10, 20, 276)
+
+	assert.False(t, validator.Active(9))
+	assert.True(t, validator.Active(10))
+	assert.True(t, validator.Active(19))
+	assert.False(t, validator.Active(20))
+
+	assert.False(t, validator.IsSlashable(9))
+	assert.True(t, validator.IsSlashable(10))
+	assert.True(t, validator.IsSlashable(275))
+	assert.False(t, validator.IsSlashable(276))
+
+	validator.SetSlashed(true)
+	assert.False(t, validator.IsSlashable(10))
+}
+
+func TestValidatorZeroRoot(t *testing.T) {
+	v := NewValidator()
+	require.True(t, v.IsZero())
+	expected, err := v.hashSSZ()
+	require.NoError(t, err)
+	root, err := v.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+
+	for i := range v {
+		v[i] = 1
+		require.False(t, v.IsZero())
+		expected, err := v.hashSSZ()
+		require.NoError(t, err)
+		root, err := v.HashSSZ()
+		require.NoError(t, err)
+		require.Equal(t, expected, root)
+		require.NotEqual(t, zeroValidatorRoot, root)
+		v[i] = 0
+	}
+}
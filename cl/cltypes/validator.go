@@ -1,61 +1,291 @@
 package cltypes
 
 import (
+	"encoding/binary"
 	"encoding/json"
+	"fmt"
+	"io"
+	"runtime"
+
+	"github.com/Giulio2002/bls"
 
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/common/length"
 	"github.com/ledgerwatch/erigon-lib/types/clonable"
 	"github.com/ledgerwatch/erigon-lib/types/ssz"
 
+	"github.com/ledgerwatch/erigon/cl/clparams"
 	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
 	"github.com/ledgerwatch/erigon/cl/merkle_tree"
 	ssz2 "github.com/ledgerwatch/erigon/cl/ssz"
+	"github.com/ledgerwatch/erigon/cl/utils"
+	"golang.org/x/sync/errgroup"
 )
 
 const (
 	DepositProofLength = 33
 	SyncCommitteeSize  = 512
+	// GweiPerEther is the number of gwei, the unit of deposit amounts and balances, in an ether.
+	GweiPerEther = 1_000_000_000
 )
 
-type DepositData struct {
+// Withdrawal credentials prefixes, the first byte of the withdrawal credentials.
+const (
+	BLSWithdrawalPrefix         byte = 0x00
+	ETH1AddressWithdrawalPrefix byte = 0x01
+)
+
+var (
+	_ ssz2.SizedObjectSSZ = (*DepositMessage)(nil)
+	_ ssz2.SizedObjectSSZ = (*DepositData)(nil)
+	_ ssz2.SizedObjectSSZ = (*Deposit)(nil)
+	_ ssz2.SizedObjectSSZ = (*VoluntaryExit)(nil)
+	_ ssz2.SizedObjectSSZ = (*SignedVoluntaryExit)(nil)
+)
+
+// DepositMessage is the part of the DepositData covered by the deposit signature.
+type DepositMessage struct {
 	PubKey                libcommon.Bytes48 `json:"pubkey"`
 	WithdrawalCredentials libcommon.Hash    `json:"withdrawal_credentials"`
 	Amount                uint64            `json:"amount,string"`
+}
+
+func (d *DepositMessage) EncodeSSZ(dst []byte) ([]byte, error) {
+	return ssz2.MarshalSSZ(dst, d.PubKey[:], d.WithdrawalCredentials[:], &d.Amount)
+}
+
+func (d *DepositMessage) DecodeSSZ(buf []byte, version int) error {
+	if len(buf) < d.EncodingSizeSSZ() {
+		return fmt.Errorf("[DepositMessage] err: %w", ssz.ErrLowBufferSize)
+	}
+	if err := ssz2.UnmarshalSSZ(buf, version, d.PubKey[:], d.WithdrawalCredentials[:], &d.Amount); err != nil {
+		return fmt.Errorf("[DepositMessage] err: %w", err)
+	}
+	return nil
+}
+
+func (d *DepositMessage) EncodingSizeSSZ() int {
+	return 88
+}
+
+func (d *DepositMessage) HashSSZ() ([32]byte, error) {
+	return merkle_tree.HashTreeRoot(d.PubKey[:], d.WithdrawalCredentials[:], d.Amount)
+}
+
+func (*DepositMessage) Static() bool {
+	return true
+}
+
+func (d *DepositMessage) Equal(other *DepositMessage) bool {
+	if d == nil || other == nil {
+		return d == other
+	}
+	return *d == *other
+}
+
+func (d *DepositMessage) Copy() *DepositMessage {
+	copied := *d
+	return &copied
+}
+
+type DepositData struct {
+	PubKey                libcommon.Bytes48 `json:"pubkey"`
+	WithdrawalCredentials libcommon.Hash    `json:"withdrawal_credentials"`
+	Amount                uint64            `json:"amount,string"` // in gwei
 	Signature             libcommon.Bytes96 `json:"signature"`
 }
 
+// DepositDataFromLog assembles a DepositData from the fields of a DepositEvent log of the deposit contract,
+// once ABI decoded. The contract emits the amount and the deposit index as 8 bytes little endian integers;
+// the index is not part of the deposit data and is only checked to be well formed.
+func DepositDataFromLog(pubkey, withdrawalCredentials, amount, signature, index []byte) (*DepositData, error) {
+	if len(pubkey) != length.Bytes48 {
+		return nil, fmt.Errorf("[DepositData] err: invalid public key length %d", len(pubkey))
+	}
+	if len(withdrawalCredentials) != length.Hash {
+		return nil, fmt.Errorf("[DepositData] err: invalid withdrawal credentials length %d", len(withdrawalCredentials))
+	}
+	if len(amount) != 8 {
+		return nil, fmt.Errorf("[DepositData] err: invalid amount length %d", len(amount))
+	}
+	if len(signature) != length.Bytes96 {
+		return nil, fmt.Errorf("[DepositData] err: invalid signature length %d", len(signature))
+	}
+	if len(index) != 8 {
+		return nil, fmt.Errorf("[DepositData] err: invalid index length %d", len(index))
+	}
+	d := &DepositData{Amount: binary.LittleEndian.Uint64(amount)}
+	copy(d.PubKey[:], pubkey)
+	copy(d.WithdrawalCredentials[:], withdrawalCredentials)
+	copy(d.Signature[:], signature)
+	return d, nil
+}
+
 func (d *DepositData) EncodeSSZ(dst []byte) ([]byte, error) {
-	return ssz2.MarshalSSZ(dst, d.PubKey[:], d.WithdrawalCredentials[:], ssz.Uint64SSZ(d.Amount), d.Signature[:])
+	return ssz2.MarshalSSZ(dst, d.PubKey[:], d.WithdrawalCredentials[:], &d.Amount, d.Signature[:])
+}
+
+func (d *DepositData) EncodeSSZTo(w io.Writer) (int, error) {
+	return ssz2.MarshalSSZTo(w, d.PubKey[:], d.WithdrawalCredentials[:], d.Amount, d.Signature[:])
 }
 
 func (d *DepositData) DecodeSSZ(buf []byte, version int) error {
-	return ssz2.UnmarshalSSZ(buf, version, d.PubKey[:], d.WithdrawalCredentials[:], &d.Amount, d.Signature[:])
+	if len(buf) < d.EncodingSizeSSZ() {
+		return fmt.Errorf("[DepositData] err: %w", ssz.ErrLowBufferSize)
+	}
+	if err := ssz2.UnmarshalSSZ(buf, version, d.PubKey[:], d.WithdrawalCredentials[:], &d.Amount, d.Signature[:]); err != nil {
+		return fmt.Errorf("[DepositData] err: %w", err)
+	}
+	return nil
 }
 
 func (d *DepositData) EncodingSizeSSZ() int {
 	return 184
 }
 
+// zeroDepositDataRoot is the root of the default DepositData.
+var zeroDepositDataRoot, _ = (&DepositData{}).hashSSZ()
+
+// IsZero reports whether all the fields of the deposit data are zero.
+func (d *DepositData) IsZero() bool {
+	return *d == DepositData{}
+}
+
 func (d *DepositData) HashSSZ() ([32]byte, error) {
-	return merkle_tree.HashTreeRoot(d.PubKey[:], d.WithdrawalCredentials[:], d.Amount, d.Signature[:])
+	if d.IsZero() {
+		return zeroDepositDataRoot, nil
+	}
+	return d.hashSSZ()
+}
+
+func (d *DepositData) hashSSZ() ([32]byte, error) {
+	return merkle_tree.HashTreeRoot(d.getSchema()...)
+}
+
+func (d *DepositData) getSchema() []interface{} {
+	return []interface{}{d.PubKey[:], d.WithdrawalCredentials[:], d.Amount, d.Signature[:]}
+}
+
+// GenerateProof returns the merkle proof of the given field (0 is the pubkey, 3 is the signature) against
+// the DepositData root. It can be checked with merkle_tree.VerifyProof.
+func (d *DepositData) GenerateProof(fieldIndex int) ([][32]byte, error) {
+	schema := d.getSchema()
+	if fieldIndex < 0 || fieldIndex >= len(schema) {
+		return nil, fmt.Errorf("[DepositData] err: field index %d out of range", fieldIndex)
+	}
+	return merkle_tree.MerkleProof(2, fieldIndex, schema...)
+}
+
+// Message returns the signed part of the deposit.
+func (d *DepositData) Message() *DepositMessage {
+	return &DepositMessage{
+		PubKey:                d.PubKey,
+		WithdrawalCredentials: d.WithdrawalCredentials,
+		Amount:                d.Amount,
+	}
 }
 
 func (d *DepositData) MessageHash() ([32]byte, error) {
-	return merkle_tree.HashTreeRoot(d.PubKey[:], d.WithdrawalCredentials[:], d.Amount)
+	return d.Message().HashSSZ()
+}
+
+// VerifyDepositSignature checks the deposit signature against the deposit's own public key, signed over
+// the deposit message (pubkey, withdrawal credentials and amount) with the given deposit domain, the one of
+// the DomainDeposit type of the beacon config and the genesis fork version.
+func (d *DepositData) VerifyDepositSignature(domain [32]byte) (bool, error) {
+	root, err := d.MessageHash()
+	if err != nil {
+		return false, err
+	}
+	signingRoot := merkle_tree.SigningRoot(root, domain)
+	return bls.Verify(d.Signature[:], signingRoot[:], d.PubKey[:])
+}
+
+// ValidatePubKey checks that the public key deserializes to a valid BLS public key: a point of G1 in its
+// prime order subgroup other than the point at infinity.
+func (d *DepositData) ValidatePubKey() error {
+	if _, err := bls.NewPublicKeyFromBytes(d.PubKey[:]); err != nil {
+		return fmt.Errorf("[DepositData] err: invalid public key %x: %w", d.PubKey[:], err)
+	}
+	return nil
+}
+
+// WithdrawalCredentialsType returns the prefix byte of the withdrawal credentials.
+func (d *DepositData) WithdrawalCredentialsType() byte {
+	return d.WithdrawalCredentials[0]
+}
+
+// WithdrawalAddress returns the execution address of eth1 withdrawal credentials. It returns false if the
+// credentials do not have the eth1 address prefix or if the 11 bytes before the address are not zero.
+func (d *DepositData) WithdrawalAddress() (libcommon.Address, bool) {
+	if d.WithdrawalCredentialsType() != ETH1AddressWithdrawalPrefix {
+		return libcommon.Address{}, false
+	}
+	for _, b := range d.WithdrawalCredentials[1:12] {
+		if b != 0 {
+			return libcommon.Address{}, false
+		}
+	}
+	return libcommon.BytesToAddress(d.WithdrawalCredentials[12:]), true
+}
+
+// AmountInEther returns the deposit amount, which is in gwei, in ether.
+func (d *DepositData) AmountInEther() float64 {
+	return float64(d.Amount) / GweiPerEther
+}
+
+// IsValidAmount reports whether the deposit contract accepts the amount: at least MIN_DEPOSIT_AMOUNT gwei,
+// which the contract fixes to 1 ether on every network. There is no upper bound, a deposit above
+// MAX_EFFECTIVE_BALANCE is credited in full and only its excess does not count for staking.
+func (d *DepositData) IsValidAmount() bool {
+	return d.Amount >= clparams.MainnetBeaconConfig.MinDepositAmount
 }
 
 func (*DepositData) Static() bool {
 	return true
 }
 
+func (d *DepositData) Equal(other *DepositData) bool {
+	if d == nil || other == nil {
+		return d == other
+	}
+	return *d == *other
+}
+
+func (d *DepositData) Copy() *DepositData {
+	copied := *d
+	return &copied
+}
+
 type Deposit struct {
 	// Merkle proof is used for deposits
 	Proof solid.HashVectorSSZ `json:"proof"` // 33 X 32 size.
 	Data  *DepositData        `json:"data"`
 }
 
+// NewDepositProof returns the proof vector of a deposit holding the given branch. The vector stores its
+// hashes in a single flat buffer.
+func NewDepositProof(branch [DepositProofLength]libcommon.Hash) solid.HashVectorSSZ {
+	proof := solid.NewHashVector(DepositProofLength)
+	for i := range branch {
+		proof.Set(i, branch[i])
+	}
+	return proof
+}
+
+// ProofBranch returns a copy of the deposit proof as a fixed size array.
+func (d *Deposit) ProofBranch() (branch [DepositProofLength]libcommon.Hash, err error) {
+	if d.Proof == nil || d.Proof.Length() != DepositProofLength {
+		return branch, fmt.Errorf("[Deposit] err: bad proof")
+	}
+	for i := range branch {
+		branch[i] = d.Proof.Get(i)
+	}
+	return branch, nil
+}
+
 func (d *Deposit) UnmarshalJSON(buf []byte) error {
-	d.Proof = solid.NewHashVector(33)
+	d.Proof = solid.NewHashVector(DepositProofLength)
 	d.Data = new(DepositData)
 
 	return json.Unmarshal(buf, &struct {
@@ -68,19 +298,167 @@ func (d *Deposit) EncodeSSZ(dst []byte) ([]byte, error) {
 	return ssz2.MarshalSSZ(dst, d.Proof, d.Data)
 }
 
+func (d *Deposit) EncodeSSZTo(w io.Writer) (int, error) {
+	return ssz2.MarshalSSZTo(w, d.Proof, d.Data)
+}
+
+// DecodeSSZ decodes the deposit in buf. A deposit that already holds a proof vector of DepositProofLength
+// hashes and its data is decoded into them in place, which saves allocations when the same object is decoded
+// over and over. Anything still referencing the previous Proof or Data then sees the new deposit: callers
+// keeping them across decodes must Copy the deposit first.
 func (d *Deposit) DecodeSSZ(buf []byte, version int) error {
-	d.Proof = solid.NewHashVector(33)
-	d.Data = new(DepositData)
+	if len(buf) < d.EncodingSizeSSZ() {
+		return fmt.Errorf("[Deposit] err: %w", ssz.ErrLowBufferSize)
+	}
+	if d.Proof == nil || !d.Proof.Static() || d.Proof.Length() != DepositProofLength {
+		d.Proof = solid.NewHashVector(DepositProofLength)
+	}
+	if d.Data == nil {
+		d.Data = new(DepositData)
+	}
 
-	return ssz2.UnmarshalSSZ(buf, version, d.Proof, d.Data)
+	if err := ssz2.UnmarshalSSZ(buf, version, d.Proof, d.Data); err != nil {
+		return fmt.Errorf("[Deposit] err: %w", err)
+	}
+	return nil
 }
 
 func (d *Deposit) EncodingSizeSSZ() int {
-	return 1240
+	return DepositProofLength*length.Hash + new(DepositData).EncodingSizeSSZ()
 }
 
 func (d *Deposit) HashSSZ() ([32]byte, error) {
-	return merkle_tree.HashTreeRoot(d.Proof, d.Data)
+	return d.hashSSZWithScratch(nil)
+}
+
+// hashSSZWithScratch computes the root of the deposit, merkleizing the proof in the given scratch leaves.
+func (d *Deposit) hashSSZWithScratch(scratch [][32]byte) ([32]byte, error) {
+	if d.Proof == nil || d.Data == nil {
+		return [32]byte{}, fmt.Errorf("[Deposit] err: incomplete deposit")
+	}
+	if d.Proof.Length() != DepositProofLength {
+		return [32]byte{}, fmt.Errorf("[Deposit] err: bad proof length %d", d.Proof.Length())
+	}
+	scratch = scratch[:0]
+	for i := 0; i < DepositProofLength; i++ {
+		scratch = append(scratch, d.Proof.Get(i))
+	}
+	proofRoot, err := merkle_tree.MerkleizeVector(scratch, merkle_tree.NextPowerOfTwo(DepositProofLength))
+	if err != nil {
+		return [32]byte{}, err
+	}
+	dataRoot, err := d.Data.HashSSZ()
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return utils.Sha256(proofRoot[:], dataRoot[:]), nil
+}
+
+// DepositRoots returns the roots of the given deposits, in the same order. The deposits are split across at
+// most runtime.NumCPU() goroutines, each reusing its own scratch leaves for the proofs.
+func DepositRoots(deposits []*Deposit) ([][32]byte, error) {
+	roots := make([][32]byte, len(deposits))
+	if len(deposits) == 0 {
+		return roots, nil
+	}
+	workers := runtime.NumCPU()
+	batchSize := (len(deposits) + workers - 1) / workers
+	var g errgroup.Group
+	for from := 0; from < len(deposits); from += batchSize {
+		from, to := from, from+batchSize
+		if to > len(deposits) {
+			to = len(deposits)
+		}
+		g.Go(func() error {
+			scratch := make([][32]byte, 0, merkle_tree.NextPowerOfTwo(DepositProofLength))
+			for i := from; i < to; i++ {
+				if deposits[i] == nil {
+					return fmt.Errorf("[Deposit] err: nil deposit at index %d", i)
+				}
+				root, err := deposits[i].hashSSZWithScratch(scratch)
+				if err != nil {
+					return fmt.Errorf("deposit %d: %w", i, err)
+				}
+				roots[i] = root
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+	return roots, nil
+}
+
+// VerifyProof checks the deposit inclusion proof of the deposit data at depositIndex against the eth1 deposit root.
+// The last element of the proof is the deposit count mixed into the deposit tree root.
+func (d *Deposit) VerifyProof(depositIndex uint64, depositRoot [32]byte) (bool, error) {
+	return d.VerifyProofWithDepth(merkle_tree.DepositContractTreeDepth, depositIndex, depositRoot)
+}
+
+// VerifyProofWithDepth is VerifyProof for a deposit tree of depth treeDepth, as set by the DepositContractTreeDepth
+// of the beacon config. The proof must hold treeDepth+1 hashes.
+func (d *Deposit) VerifyProofWithDepth(treeDepth, depositIndex uint64, depositRoot [32]byte) (bool, error) {
+	if d.Data == nil || d.Proof == nil {
+		return false, fmt.Errorf("[Deposit] err: incomplete deposit")
+	}
+	if uint64(d.Proof.Length()) != treeDepth+1 {
+		return false, fmt.Errorf("[Deposit] err: proof of %d hashes for a deposit tree of depth %d", d.Proof.Length(), treeDepth)
+	}
+	leaf, err := d.Data.HashSSZ()
+	if err != nil {
+		return false, err
+	}
+	branch := make([]libcommon.Hash, 0, d.Proof.Length())
+	d.Proof.Range(func(_ int, h libcommon.Hash, _ int) bool {
+		branch = append(branch, h)
+		return true
+	})
+	return utils.IsValidMerkleBranch(leaf, branch, treeDepth+1, depositIndex, depositRoot), nil
+}
+
+func (*Deposit) Static() bool {
+	return true
+}
+
+// Equal returns whether both deposits carry the same data and the same proof, hash by hash.
+func (d *Deposit) Equal(other *Deposit) bool {
+	if d == nil || other == nil {
+		return d == other
+	}
+	if !d.Data.Equal(other.Data) {
+		return false
+	}
+	if d.Proof == nil || other.Proof == nil {
+		return d.Proof == other.Proof
+	}
+	if d.Proof.Length() != other.Proof.Length() {
+		return false
+	}
+	for i := 0; i < d.Proof.Length(); i++ {
+		if d.Proof.Get(i) != other.Proof.Get(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// Copy returns a deep copy of the deposit, the proof and data are not shared with the original.
+func (d *Deposit) Copy() *Deposit {
+	copied := &Deposit{}
+	if d.Proof != nil {
+		copied.Proof = d.Proof.Clone().(solid.HashVectorSSZ)
+		d.Proof.CopyTo(copied.Proof)
+	}
+	if d.Data != nil {
+		copied.Data = d.Data.Copy()
+	}
+	return copied
+}
+
+// NewDepositList returns an empty SSZ list of deposits, as carried by a block body.
+func NewDepositList() *solid.ListSSZ[*Deposit] {
+	return solid.NewStaticListSSZ[*Deposit](MaxDeposits, new(Deposit).EncodingSizeSSZ())
 }
 
 type VoluntaryExit struct {
@@ -89,7 +467,13 @@ type VoluntaryExit struct {
 }
 
 func (e *VoluntaryExit) EncodeSSZ(buf []byte) ([]byte, error) {
-	return ssz2.MarshalSSZ(buf, e.Epoch, e.ValidatorIndex)
+	buf = ssz.AppendUint64SSZ(buf, e.Epoch)
+	buf = ssz.AppendUint64SSZ(buf, e.ValidatorIndex)
+	return buf, nil
+}
+
+func (e *VoluntaryExit) EncodeSSZTo(w io.Writer) (int, error) {
+	return ssz2.MarshalSSZTo(w, e.Epoch, e.ValidatorIndex)
 }
 
 func (*VoluntaryExit) Clone() clonable.Clonable {
@@ -101,7 +485,10 @@ func (*VoluntaryExit) Static() bool {
 }
 
 func (e *VoluntaryExit) DecodeSSZ(buf []byte, version int) error {
-	return ssz2.UnmarshalSSZ(buf, 0, &e.Epoch, &e.ValidatorIndex)
+	if err := ssz2.UnmarshalSSZ(buf, 0, &e.Epoch, &e.ValidatorIndex); err != nil {
+		return fmt.Errorf("[VoluntaryExit] err: %w", err)
+	}
+	return nil
 }
 
 func (e *VoluntaryExit) HashSSZ() ([32]byte, error) {
@@ -112,18 +499,52 @@ func (*VoluntaryExit) EncodingSizeSSZ() int {
 	return 16
 }
 
+func (e *VoluntaryExit) Equal(other *VoluntaryExit) bool {
+	if e == nil || other == nil {
+		return e == other
+	}
+	return *e == *other
+}
+
+func (e *VoluntaryExit) Copy() *VoluntaryExit {
+	copied := *e
+	return &copied
+}
+
 type SignedVoluntaryExit struct {
 	VoluntaryExit *VoluntaryExit    `json:"message"`
 	Signature     libcommon.Bytes96 `json:"signature"`
 }
 
+// NewSignedVoluntaryExitList returns an empty SSZ list of signed voluntary exits, as carried by a block body.
+func NewSignedVoluntaryExitList() *solid.ListSSZ[*SignedVoluntaryExit] {
+	return solid.NewStaticListSSZ[*SignedVoluntaryExit](MaxVoluntaryExits, new(SignedVoluntaryExit).EncodingSizeSSZ())
+}
+
+func (e *SignedVoluntaryExit) MarshalJSON() ([]byte, error) {
+	if e.VoluntaryExit == nil {
+		return nil, fmt.Errorf("[SignedVoluntaryExit] err: nil message")
+	}
+	return json.Marshal(struct {
+		VoluntaryExit *VoluntaryExit    `json:"message"`
+		Signature     libcommon.Bytes96 `json:"signature"`
+	}{e.VoluntaryExit, e.Signature})
+}
+
 func (e *SignedVoluntaryExit) EncodeSSZ(dst []byte) ([]byte, error) {
 	return ssz2.MarshalSSZ(dst, e.VoluntaryExit, e.Signature[:])
 }
 
+func (e *SignedVoluntaryExit) EncodeSSZTo(w io.Writer) (int, error) {
+	return ssz2.MarshalSSZTo(w, e.VoluntaryExit, e.Signature[:])
+}
+
 func (e *SignedVoluntaryExit) DecodeSSZ(buf []byte, version int) error {
 	e.VoluntaryExit = new(VoluntaryExit)
-	return ssz2.UnmarshalSSZ(buf, version, e.VoluntaryExit, e.Signature[:])
+	if err := ssz2.UnmarshalSSZ(buf, version, e.VoluntaryExit, e.Signature[:]); err != nil {
+		return fmt.Errorf("[SignedVoluntaryExit] err: %w", err)
+	}
+	return nil
 }
 
 func (e *SignedVoluntaryExit) HashSSZ() ([32]byte, error) {
@@ -133,3 +554,36 @@ func (e *SignedVoluntaryExit) HashSSZ() ([32]byte, error) {
 func (e *SignedVoluntaryExit) EncodingSizeSSZ() int {
 	return 96 + e.VoluntaryExit.EncodingSizeSSZ()
 }
+
+func (*SignedVoluntaryExit) Static() bool {
+	return true
+}
+
+func (e *SignedVoluntaryExit) Equal(other *SignedVoluntaryExit) bool {
+	if e == nil || other == nil {
+		return e == other
+	}
+	return e.Signature == other.Signature && e.VoluntaryExit.Equal(other.VoluntaryExit)
+}
+
+func (e *SignedVoluntaryExit) Copy() *SignedVoluntaryExit {
+	copied := &SignedVoluntaryExit{Signature: e.Signature}
+	if e.VoluntaryExit != nil {
+		copied.VoluntaryExit = e.VoluntaryExit.Copy()
+	}
+	return copied
+}
+
+// VerifySignature checks the exit signature against the validator public key for the given signing domain,
+// which is of the DomainVoluntaryExit type of the beacon config.
+func (e *SignedVoluntaryExit) VerifySignature(pubkey [48]byte, domain [32]byte) (bool, error) {
+	if e.VoluntaryExit == nil {
+		return false, fmt.Errorf("[SignedVoluntaryExit] err: nil message")
+	}
+	root, err := e.VoluntaryExit.HashSSZ()
+	if err != nil {
+		return false, err
+	}
+	signingRoot := merkle_tree.SigningRoot(root, domain)
+	return bls.Verify(e.Signature[:], signingRoot[:], pubkey[:])
+}
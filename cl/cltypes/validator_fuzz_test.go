@@ -0,0 +1,62 @@
+//go:build !nofuzz
+
+package cltypes_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+	ssz2 "github.com/ledgerwatch/erigon/cl/ssz"
+)
+
+// fuzzDecodeSSZ decodes in into a fresh object and checks that decoding never panics and that a successfully
+// decoded object re-encodes to the bytes it was decoded from and decodes back to the same encoding.
+func fuzzDecodeSSZ(t *testing.T, in []byte, newObj func() ssz2.SizedObjectSSZ) {
+	obj := newObj()
+	if err := obj.DecodeSSZ(in, 0); err != nil {
+		return
+	}
+	encoded, err := obj.EncodeSSZ(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, in[:len(encoded)]) {
+		t.Fatalf("re-encoding differs from the input: %x != %x", encoded, in[:len(encoded)])
+	}
+	decoded := newObj()
+	if err := decoded.DecodeSSZ(encoded, 0); err != nil {
+		t.Fatal(err)
+	}
+	reencoded, err := decoded.EncodeSSZ(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(encoded, reencoded) {
+		t.Fatalf("round trip differs: %x != %x", encoded, reencoded)
+	}
+}
+
+func FuzzDepositData(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(make([]byte, 184))
+	f.Fuzz(func(t *testing.T, in []byte) {
+		fuzzDecodeSSZ(t, in, func() ssz2.SizedObjectSSZ { return &cltypes.DepositData{} })
+	})
+}
+
+func FuzzDeposit(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(make([]byte, 1240))
+	f.Fuzz(func(t *testing.T, in []byte) {
+		fuzzDecodeSSZ(t, in, func() ssz2.SizedObjectSSZ { return &cltypes.Deposit{} })
+	})
+}
+
+func FuzzSignedVoluntaryExit(f *testing.F) {
+	f.Add([]byte{})
+	f.Add(make([]byte, 112))
+	f.Fuzz(func(t *testing.T, in []byte) {
+		fuzzDecodeSSZ(t, in, func() ssz2.SizedObjectSSZ { return &cltypes.SignedVoluntaryExit{} })
+	})
+}
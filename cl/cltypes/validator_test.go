@@ -1,12 +1,25 @@
 package cltypes_test
 
 import (
+	"bytes"
+	_ "embed"
+	"encoding/binary"
 	"encoding/hex"
+	"encoding/json"
+	"io"
+	"math"
+	"strings"
 	"testing"
 
+	"github.com/Giulio2002/bls"
 	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
+	"github.com/ledgerwatch/erigon/accounts/abi"
+	"github.com/ledgerwatch/erigon/cl/clparams"
 	"github.com/ledgerwatch/erigon/cl/cltypes"
 	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
+	"github.com/ledgerwatch/erigon/cl/merkle_tree"
+	ssz2 "github.com/ledgerwatch/erigon/cl/ssz"
 	"github.com/ledgerwatch/erigon/cl/utils"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -38,6 +51,36 @@ func TestSignedVoluntaryExit(t *testing.T) {
 	assert.Equal(t, signedExit.VoluntaryExit.Epoch, decodedExit.VoluntaryExit.Epoch, "Decoded SignedVoluntaryExit has incorrect epoch")
 	assert.Equal(t, signedExit.VoluntaryExit.ValidatorIndex, decodedExit.VoluntaryExit.ValidatorIndex, "Decoded SignedVoluntaryExit has incorrect validator index")
 	assert.Equal(t, signedExit.Signature, decodedExit.Signature, "Decoded SignedVoluntaryExit has incorrect signature")
+
+	root, err := signedExit.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("0x5bfe77092b822b2f05f406d3395299b62c7d36671630d212a5b17beb19f63c04"), common.Hash(root))
+}
+
+func TestVoluntaryExitEncodeSSZ(t *testing.T) {
+	exit := &cltypes.VoluntaryExit{Epoch: 0x0102030405060708, ValidatorIndex: 0x1112131415161718}
+	encoded, err := exit.EncodeSSZ([]byte{0xff})
+	require.NoError(t, err)
+	// The epoch then the validator index, both little endian, appended to the buffer.
+	require.Equal(t, []byte{
+		0xff,
+		0x08, 0x07, 0x06, 0x05, 0x04, 0x03, 0x02, 0x01,
+		0x18, 0x17, 0x16, 0x15, 0x14, 0x13, 0x12, 0x11,
+	}, encoded)
+	require.Len(t, encoded[1:], exit.EncodingSizeSSZ())
+	var w bytes.Buffer
+	_, err = exit.EncodeSSZTo(&w)
+	require.NoError(t, err)
+	require.Equal(t, encoded[1:], w.Bytes())
+}
+
+func TestVoluntaryExitHashSSZ(t *testing.T) {
+	exit := &cltypes.VoluntaryExit{Epoch: 5, ValidatorIndex: 10}
+	root, err := exit.HashSSZ()
+	require.NoError(t, err)
+	// Container of two uint64 leaves: sha256(uint64_chunk(5) || uint64_chunk(10)).
+	require.Equal(t, common.HexToHash("0xe6d9daabba056acedd2d961109fed101b9c86e6731dbc4043b6cba92bc443e6d"), common.Hash(root))
+	require.Equal(t, utils.Sha256(merkle_tree.Uint64Root(5).Bytes(), merkle_tree.Uint64Root(10).Bytes()), root)
 }
 
 func TestDepositData(t *testing.T) {
@@ -124,3 +167,1042 @@ func TestValidatorNonSlashed(t *testing.T) {
 	assert.False(t, decodedValidator.IsSlashable(1))
 
 }
+
+func TestDepositDataZeroRoot(t *testing.T) {
+	zero := &cltypes.DepositData{}
+	require.True(t, zero.IsZero())
+	expected, err := merkle_tree.HashTreeRoot(zero.PubKey[:], zero.WithdrawalCredentials[:], zero.Amount, zero.Signature[:])
+	require.NoError(t, err)
+	root, err := zero.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+
+	for _, d := range []*cltypes.DepositData{
+		{PubKey: [48]byte{47: 1}},
+		{WithdrawalCredentials: [32]byte{1}},
+		{Amount: 1},
+		{Signature: [96]byte{95: 1}},
+	} {
+		require.False(t, d.IsZero())
+		expected, err := merkle_tree.HashTreeRoot(d.PubKey[:], d.WithdrawalCredentials[:], d.Amount, d.Signature[:])
+		require.NoError(t, err)
+		root, err := d.HashSSZ()
+		require.NoError(t, err)
+		require.Equal(t, expected, root)
+	}
+}
+
+func TestDepositDataWithdrawalAddress(t *testing.T) {
+	address := common.HexToAddress("0x8ba1f109551bd432803012645ac136ddd64dba72")
+	eth1Credentials := common.Hash{0: cltypes.ETH1AddressWithdrawalPrefix}
+	copy(eth1Credentials[12:], address[:])
+	blsCredentials := common.HexToHash("0x00f50428677c60f997aadeab24aabf7fceaef491c96a52b463ae91f95611cf71")
+
+	tests := []struct {
+		name        string
+		credentials common.Hash
+		typ         byte
+		address     common.Address
+		ok          bool
+	}{
+		{name: "eth1", credentials: eth1Credentials, typ: cltypes.ETH1AddressWithdrawalPrefix, address: address, ok: true},
+		{name: "eth1 zero address", credentials: common.Hash{0: 0x01}, typ: cltypes.ETH1AddressWithdrawalPrefix, ok: true},
+		{name: "bls", credentials: blsCredentials, typ: cltypes.BLSWithdrawalPrefix},
+		{name: "unknown prefix", credentials: func() common.Hash { c := eth1Credentials; c[0] = 0x02; return c }(), typ: 0x02},
+		{name: "eth1 non zero padding", credentials: func() common.Hash { c := eth1Credentials; c[11] = 1; return c }(), typ: cltypes.ETH1AddressWithdrawalPrefix},
+		{name: "eth1 non zero first padding byte", credentials: func() common.Hash { c := eth1Credentials; c[1] = 1; return c }(), typ: cltypes.ETH1AddressWithdrawalPrefix},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &cltypes.DepositData{WithdrawalCredentials: tt.credentials}
+			require.Equal(t, tt.typ, d.WithdrawalCredentialsType())
+			address, ok := d.WithdrawalAddress()
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.address, address)
+		})
+	}
+}
+
+func TestDepositDataAmount(t *testing.T) {
+	cfg := &clparams.MainnetBeaconConfig
+	for _, test := range []struct {
+		amount uint64
+		ether  float64
+		valid  bool
+	}{
+		{0, 0, false},
+		{cfg.MinDepositAmount - 1, 0.999999999, false},
+		{cfg.MinDepositAmount, 1, true},
+		{cfg.MaxEffectiveBalance - 1, 31.999999999, true},
+		{cfg.MaxEffectiveBalance, 32, true},
+		// The excess over the maximum effective balance is credited, not rejected.
+		{cfg.MaxEffectiveBalance + 1, 32.000000001, true},
+		{1000 * cltypes.GweiPerEther, 1000, true},
+	} {
+		depositData := &cltypes.DepositData{Amount: test.amount}
+		require.Equal(t, test.valid, depositData.IsValidAmount(), "amount %d", test.amount)
+		require.InDelta(t, test.ether, depositData.AmountInEther(), 1e-12, "amount %d", test.amount)
+	}
+}
+
+func TestDepositDataValidatePubKey(t *testing.T) {
+	privateKey, err := bls.NewPrivateKeyFromBytes(common.Hex2Bytes("3f8a5c1a25c2b3b0b9e1f1d6d1e3d8b8a7d4c5e6f7a8b9c0d1e2f3a4b5c6d7e8"))
+	require.NoError(t, err)
+	valid := &cltypes.DepositData{}
+	copy(valid.PubKey[:], bls.CompressPublicKey(privateKey.PublicKey()))
+	require.NoError(t, valid.ValidatePubKey())
+
+	allFF := &cltypes.DepositData{}
+	for i := range allFF.PubKey {
+		allFF.PubKey[i] = 0xff
+	}
+	require.ErrorContains(t, allFF.ValidatePubKey(), "invalid public key")
+	// The compressed point at infinity deserializes but is not a valid public key.
+	require.Error(t, (&cltypes.DepositData{PubKey: [48]byte{0xc0}}).ValidatePubKey())
+	require.Error(t, (&cltypes.DepositData{}).ValidatePubKey())
+}
+
+// depositContractABI is the DepositEvent of the deposit contract.
+const depositContractABI = `[{"anonymous":false,"inputs":[{"indexed":false,"internalType":"bytes","name":"pubkey","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"withdrawal_credentials","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"amount","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"signature","type":"bytes"},{"indexed":false,"internalType":"bytes","name":"index","type":"bytes"}],"name":"DepositEvent","type":"event"}]`
+
+func TestDepositDataFromLog(t *testing.T) {
+	// First deposit of the slot 8322 block of the beacon API test data.
+	expected := &cltypes.DepositData{
+		PubKey:                common.Bytes48(common.Hex2Bytes("a19c8e80ddc1caad60a172b66eb24e83ef200d77034b3e16bbee4d95e929a5c1a473563973338d22e7a566fdbd352f65")),
+		WithdrawalCredentials: common.HexToHash("0x00edbcfc97a6985ac86187522426240ed81b6493c880d0798360149ec8ce96d8"),
+		Amount:                32000000000,
+		Signature:             common.Bytes96(common.Hex2Bytes("b9b4b512b2c67a3e89edcbef91fc0ccd88c9a8c8654c51a130ffb2ab539c22a0c6b84928e8db4ca8a9d04f2dee312c3817a2bf360b6f5f2f3d1ba69b43cf4671290f7f58621887ad4dd1c9fe6d02cc59443e12447a20b38913f67597b0e3cc93")),
+	}
+	amount := make([]byte, 8)
+	binary.LittleEndian.PutUint64(amount, expected.Amount)
+	index := make([]byte, 8)
+	binary.LittleEndian.PutUint64(index, 528)
+
+	contract, err := abi.JSON(strings.NewReader(depositContractABI))
+	require.NoError(t, err)
+	event := contract.Events["DepositEvent"]
+	logData, err := event.Inputs.Pack(expected.PubKey[:], expected.WithdrawalCredentials[:], amount, expected.Signature[:], index)
+	require.NoError(t, err)
+	values, err := event.Inputs.UnpackValues(logData)
+	require.NoError(t, err)
+	require.Len(t, values, 5)
+
+	depositData, err := cltypes.DepositDataFromLog(values[0].([]byte), values[1].([]byte), values[2].([]byte), values[3].([]byte), values[4].([]byte))
+	require.NoError(t, err)
+	require.Equal(t, expected, depositData)
+
+	// The amount is little endian: 32 ETH in gwei is 0x0773594000.
+	require.Equal(t, common.Hex2Bytes("0040597307000000"), amount)
+
+	_, err = cltypes.DepositDataFromLog(expected.PubKey[:47], expected.WithdrawalCredentials[:], amount, expected.Signature[:], index)
+	require.Error(t, err)
+	_, err = cltypes.DepositDataFromLog(expected.PubKey[:], expected.WithdrawalCredentials[:], amount[:4], expected.Signature[:], index)
+	require.Error(t, err)
+	_, err = cltypes.DepositDataFromLog(expected.PubKey[:], expected.WithdrawalCredentials[:], amount, expected.Signature[:], nil)
+	require.Error(t, err)
+}
+
+func TestDepositDataDecodeShortBuffer(t *testing.T) {
+	for _, size := range []int{0, 183} {
+		require.ErrorIs(t, new(cltypes.DepositData).DecodeSSZ(make([]byte, size), 0), ssz.ErrLowBufferSize)
+	}
+	require.NoError(t, new(cltypes.DepositData).DecodeSSZ(make([]byte, 184), 0))
+}
+
+func TestVoluntaryExitDecodeShortBuffer(t *testing.T) {
+	for _, size := range []int{0, 15} {
+		require.ErrorIs(t, new(cltypes.VoluntaryExit).DecodeSSZ(make([]byte, size), 0), ssz.ErrLowBufferSize)
+	}
+	require.NoError(t, new(cltypes.VoluntaryExit).DecodeSSZ(make([]byte, 16), 0))
+
+	for _, size := range []int{0, 16, 111} {
+		require.ErrorIs(t, new(cltypes.SignedVoluntaryExit).DecodeSSZ(make([]byte, size), 0), ssz.ErrLowBufferSize)
+	}
+	require.NoError(t, new(cltypes.SignedVoluntaryExit).DecodeSSZ(make([]byte, 112), 0))
+}
+
+func TestSignedVoluntaryExitDecodeSignature(t *testing.T) {
+	exit := &cltypes.SignedVoluntaryExit{VoluntaryExit: &cltypes.VoluntaryExit{Epoch: 3, ValidatorIndex: 4}}
+	for i := range exit.Signature {
+		exit.Signature[i] = byte(i + 1)
+	}
+	encoded, err := exit.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Len(t, encoded, 16+96)
+
+	decoded := &cltypes.SignedVoluntaryExit{}
+	require.NoError(t, decoded.DecodeSSZ(encoded, 0))
+	require.Equal(t, exit, decoded)
+
+	// A truncated signature is rejected before any of it is copied.
+	decoded = &cltypes.SignedVoluntaryExit{}
+	require.ErrorIs(t, decoded.DecodeSSZ(encoded[:len(encoded)-1], 0), ssz.ErrLowBufferSize)
+	require.Equal(t, common.Bytes96{}, decoded.Signature)
+}
+
+func TestDepositDecodeBufferSize(t *testing.T) {
+	tests := []struct {
+		name string
+		size int
+		err  error
+	}{
+		{name: "empty", size: 0, err: ssz.ErrLowBufferSize},
+		{name: "proof only", size: 33 * 32, err: ssz.ErrLowBufferSize},
+		{name: "one byte short", size: 1239, err: ssz.ErrLowBufferSize},
+		{name: "exact", size: 1240},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := new(cltypes.Deposit).DecodeSSZ(make([]byte, tt.size), 0)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func testDepositEncoding(t testing.TB, seed byte) []byte {
+	proof := solid.NewHashVector(cltypes.DepositProofLength)
+	for i := 0; i < cltypes.DepositProofLength; i++ {
+		proof.Set(i, common.Hash{seed, byte(i)})
+	}
+	deposit := &cltypes.Deposit{
+		Proof: proof,
+		Data:  &cltypes.DepositData{PubKey: [48]byte{seed}, Amount: uint64(seed), Signature: [96]byte{seed}},
+	}
+	encoded, err := deposit.EncodeSSZ(nil)
+	require.NoError(t, err)
+	return encoded
+}
+
+func TestDepositDecodeSSZReuse(t *testing.T) {
+	first, second := testDepositEncoding(t, 1), testDepositEncoding(t, 2)
+
+	deposit := new(cltypes.Deposit)
+	require.NoError(t, deposit.DecodeSSZ(first, 0))
+	proof, data := deposit.Proof, deposit.Data
+	copied := deposit.Copy()
+	require.NoError(t, deposit.DecodeSSZ(second, 0))
+	require.Same(t, data, deposit.Data)
+	require.Same(t, proof, deposit.Proof)
+	// A copy taken before is left alone.
+	firstEncoded, err := copied.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Equal(t, first, firstEncoded)
+
+	fresh := new(cltypes.Deposit)
+	require.NoError(t, fresh.DecodeSSZ(second, 0))
+	require.True(t, fresh.Equal(deposit))
+	reencoded, err := deposit.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Equal(t, second, reencoded)
+
+	// A proof of the wrong shape is replaced rather than decoded into.
+	short := &cltypes.Deposit{Proof: solid.NewHashVector(2)}
+	require.NoError(t, short.DecodeSSZ(second, 0))
+	require.True(t, fresh.Equal(short))
+	list := &cltypes.Deposit{Proof: solid.NewHashList(cltypes.DepositProofLength)}
+	require.NoError(t, list.DecodeSSZ(second, 0))
+	require.True(t, fresh.Equal(list))
+}
+
+func BenchmarkDepositDecodeSSZ(b *testing.B) {
+	encoded := testDepositEncoding(b, 1)
+	b.Run("fresh", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := new(cltypes.Deposit).DecodeSSZ(encoded, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("reused", func(b *testing.B) {
+		deposit := new(cltypes.Deposit)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if err := deposit.DecodeSSZ(encoded, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+type roundTripSSZ[T any] interface {
+	ssz2.SizedObjectSSZ
+	ssz.HashableSSZ
+	Equal(T) bool
+}
+
+// testRoundTripSSZ encodes obj, decodes the result into empty and checks that the decoded
+// object equals obj and that both the re-encoding and the root are unchanged.
+func testRoundTripSSZ[T roundTripSSZ[T]](t *testing.T, obj, empty T) {
+	t.Helper()
+	encoded, err := obj.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Len(t, encoded, obj.EncodingSizeSSZ())
+	require.NoError(t, empty.DecodeSSZ(encoded, 0))
+	require.True(t, obj.Equal(empty))
+	reencoded, err := empty.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Equal(t, encoded, reencoded)
+	root, err := obj.HashSSZ()
+	require.NoError(t, err)
+	decodedRoot, err := empty.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, root, decodedRoot)
+}
+
+func TestValidatorTypesRoundTripSSZ(t *testing.T) {
+	depositData := &cltypes.DepositData{
+		PubKey:                [48]byte{1, 2, 3},
+		WithdrawalCredentials: [32]byte{4, 5, 6},
+		Amount:                32000000000,
+		Signature:             [96]byte{7, 8, 9},
+	}
+	proof := solid.NewHashVector(cltypes.DepositProofLength)
+	for i := 0; i < cltypes.DepositProofLength; i++ {
+		proof.Set(i, common.Hash{byte(i)})
+	}
+	exit := &cltypes.VoluntaryExit{Epoch: 5, ValidatorIndex: 10}
+
+	testRoundTripSSZ(t, depositData, &cltypes.DepositData{})
+	testRoundTripSSZ(t, &cltypes.Deposit{Proof: proof, Data: depositData}, &cltypes.Deposit{})
+	testRoundTripSSZ(t, exit, &cltypes.VoluntaryExit{})
+	testRoundTripSSZ(t, &cltypes.SignedVoluntaryExit{VoluntaryExit: exit, Signature: [96]byte{1}}, &cltypes.SignedVoluntaryExit{})
+	testRoundTripSSZ(t, solid.NewSyncCommitteeFromParameters(make([]common.Bytes48, cltypes.SyncCommitteeSize), [48]byte{1}), &solid.SyncCommittee{})
+}
+
+func TestValidatorTypesEqual(t *testing.T) {
+	newDeposit := func() *cltypes.Deposit {
+		proof := solid.NewHashVector(cltypes.DepositProofLength)
+		for i := 0; i < cltypes.DepositProofLength; i++ {
+			proof.Set(i, common.Hash{byte(i)})
+		}
+		return &cltypes.Deposit{
+			Proof: proof,
+			Data:  &cltypes.DepositData{PubKey: [48]byte{1}, WithdrawalCredentials: [32]byte{2}, Amount: 3, Signature: [96]byte{4}},
+		}
+	}
+	newExit := func() *cltypes.SignedVoluntaryExit {
+		return &cltypes.SignedVoluntaryExit{VoluntaryExit: &cltypes.VoluntaryExit{Epoch: 5, ValidatorIndex: 10}, Signature: [96]byte{1}}
+	}
+
+	require.True(t, newDeposit().Equal(newDeposit()))
+	require.True(t, newDeposit().Data.Equal(newDeposit().Data))
+	require.True(t, newExit().Equal(newExit()))
+	require.True(t, newExit().VoluntaryExit.Equal(newExit().VoluntaryExit))
+
+	depositTests := []struct {
+		name   string
+		mutate func(d *cltypes.Deposit)
+	}{
+		{name: "pubkey", mutate: func(d *cltypes.Deposit) { d.Data.PubKey[47] = 1 }},
+		{name: "withdrawal credentials", mutate: func(d *cltypes.Deposit) { d.Data.WithdrawalCredentials[31] = 1 }},
+		{name: "amount", mutate: func(d *cltypes.Deposit) { d.Data.Amount++ }},
+		{name: "signature", mutate: func(d *cltypes.Deposit) { d.Data.Signature[95] = 1 }},
+		{name: "first proof hash", mutate: func(d *cltypes.Deposit) { d.Proof.Set(0, common.Hash{0xff}) }},
+		{name: "last proof hash", mutate: func(d *cltypes.Deposit) { d.Proof.Set(cltypes.DepositProofLength-1, common.Hash{0xff}) }},
+		{name: "proof length", mutate: func(d *cltypes.Deposit) { d.Proof = solid.NewHashVector(cltypes.DepositProofLength - 1) }},
+		{name: "nil proof", mutate: func(d *cltypes.Deposit) { d.Proof = nil }},
+		{name: "nil data", mutate: func(d *cltypes.Deposit) { d.Data = nil }},
+	}
+	for _, tt := range depositTests {
+		t.Run("deposit "+tt.name, func(t *testing.T) {
+			changed := newDeposit()
+			tt.mutate(changed)
+			require.False(t, newDeposit().Equal(changed))
+			require.False(t, changed.Equal(newDeposit()))
+		})
+	}
+
+	exitTests := []struct {
+		name   string
+		mutate func(e *cltypes.SignedVoluntaryExit)
+	}{
+		{name: "epoch", mutate: func(e *cltypes.SignedVoluntaryExit) { e.VoluntaryExit.Epoch++ }},
+		{name: "validator index", mutate: func(e *cltypes.SignedVoluntaryExit) { e.VoluntaryExit.ValidatorIndex++ }},
+		{name: "signature", mutate: func(e *cltypes.SignedVoluntaryExit) { e.Signature[95] = 1 }},
+		{name: "nil message", mutate: func(e *cltypes.SignedVoluntaryExit) { e.VoluntaryExit = nil }},
+	}
+	for _, tt := range exitTests {
+		t.Run("exit "+tt.name, func(t *testing.T) {
+			changed := newExit()
+			tt.mutate(changed)
+			require.False(t, newExit().Equal(changed))
+			require.False(t, changed.Equal(newExit()))
+		})
+	}
+
+	t.Run("nil", func(t *testing.T) {
+		require.True(t, (*cltypes.Deposit)(nil).Equal(nil))
+		require.False(t, (*cltypes.Deposit)(nil).Equal(newDeposit()))
+		require.False(t, newDeposit().Equal(nil))
+		require.True(t, (*cltypes.DepositData)(nil).Equal(nil))
+		require.False(t, newDeposit().Data.Equal(nil))
+		require.True(t, (*cltypes.VoluntaryExit)(nil).Equal(nil))
+		require.False(t, newExit().VoluntaryExit.Equal(nil))
+		require.True(t, (*cltypes.SignedVoluntaryExit)(nil).Equal(nil))
+		require.False(t, (*cltypes.SignedVoluntaryExit)(nil).Equal(newExit()))
+		require.False(t, newExit().Equal(nil))
+		require.True(t, (&cltypes.Deposit{}).Equal(&cltypes.Deposit{}))
+	})
+}
+
+func TestValidatorTypesCopy(t *testing.T) {
+	proof := solid.NewHashVector(cltypes.DepositProofLength)
+	for i := 0; i < cltypes.DepositProofLength; i++ {
+		proof.Set(i, common.Hash{byte(i)})
+	}
+	deposit := &cltypes.Deposit{
+		Proof: proof,
+		Data:  &cltypes.DepositData{PubKey: [48]byte{1}, WithdrawalCredentials: [32]byte{2}, Amount: 3, Signature: [96]byte{4}},
+	}
+	copied := deposit.Copy()
+	require.True(t, deposit.Equal(copied))
+	copied.Proof.Set(0, common.Hash{0xff})
+	copied.Proof.Set(cltypes.DepositProofLength-1, common.Hash{0xff})
+	copied.Data.PubKey[0] = 0xff
+	copied.Data.WithdrawalCredentials[0] = 0xff
+	copied.Data.Amount = 0
+	copied.Data.Signature[0] = 0xff
+	require.Equal(t, common.Hash{0}, deposit.Proof.Get(0))
+	require.Equal(t, common.Hash{cltypes.DepositProofLength - 1}, deposit.Proof.Get(cltypes.DepositProofLength-1))
+	require.Equal(t, &cltypes.DepositData{PubKey: [48]byte{1}, WithdrawalCredentials: [32]byte{2}, Amount: 3, Signature: [96]byte{4}}, deposit.Data)
+	require.True(t, (&cltypes.Deposit{}).Equal((&cltypes.Deposit{}).Copy()))
+
+	message := deposit.Data.Message()
+	copiedMessage := message.Copy()
+	copiedMessage.PubKey[0] = 0xff
+	require.Equal(t, byte(1), message.PubKey[0])
+
+	exit := &cltypes.SignedVoluntaryExit{VoluntaryExit: &cltypes.VoluntaryExit{Epoch: 5, ValidatorIndex: 10}, Signature: [96]byte{1}}
+	copiedExit := exit.Copy()
+	require.True(t, exit.Equal(copiedExit))
+	copiedExit.VoluntaryExit.Epoch = 0
+	copiedExit.Signature[0] = 0xff
+	require.Equal(t, uint64(5), exit.VoluntaryExit.Epoch)
+	require.Equal(t, byte(1), exit.Signature[0])
+	require.True(t, (&cltypes.SignedVoluntaryExit{}).Equal((&cltypes.SignedVoluntaryExit{}).Copy()))
+
+	committee := solid.NewSyncCommitteeFromParameters(make([]common.Bytes48, cltypes.SyncCommitteeSize), [48]byte{1})
+	copiedCommittee := committee.Copy()
+	pubKeys := copiedCommittee.GetCommittee()
+	pubKeys[0][0] = 0xff
+	copiedCommittee.SetCommittee(pubKeys)
+	copiedCommittee.SetAggregatePublicKey([48]byte{2})
+	require.Equal(t, common.Bytes48{}, committee.GetCommittee()[0])
+	require.Equal(t, common.Bytes48{1}, committee.AggregatePublicKey())
+}
+
+func TestDecodeSSZErrorContext(t *testing.T) {
+	tests := []struct {
+		name     string
+		obj      ssz2.SizedObjectSSZ
+		buf      []byte
+		contains []string
+	}{
+		{name: "deposit data", obj: &cltypes.DepositData{}, buf: make([]byte, 100), contains: []string{"[DepositData]"}},
+		{name: "deposit", obj: &cltypes.Deposit{}, buf: make([]byte, 1000), contains: []string{"[Deposit]"}},
+		{name: "voluntary exit", obj: &cltypes.VoluntaryExit{}, buf: make([]byte, 12), contains: []string{"[VoluntaryExit]", "element 1"}},
+		{name: "signed voluntary exit", obj: &cltypes.SignedVoluntaryExit{}, buf: make([]byte, 8), contains: []string{"[SignedVoluntaryExit]", "*cltypes.VoluntaryExit"}},
+		{name: "signed voluntary exit signature", obj: &cltypes.SignedVoluntaryExit{}, buf: make([]byte, 100), contains: []string{"[SignedVoluntaryExit]", "element 1"}},
+		{name: "sync aggregate", obj: &cltypes.SyncAggregate{}, buf: make([]byte, 100), contains: []string{"[SyncAggregate]"}},
+		{name: "sync committee", obj: &solid.SyncCommittee{}, buf: make([]byte, 100), contains: []string{"[SyncCommittee]"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.obj.DecodeSSZ(tt.buf, 0)
+			require.ErrorIs(t, err, ssz.ErrLowBufferSize)
+			for _, s := range tt.contains {
+				require.Contains(t, err.Error(), s)
+			}
+		})
+	}
+}
+
+func TestEncodeSSZIncompleteObjects(t *testing.T) {
+	_, err := (&cltypes.SignedVoluntaryExit{}).EncodeSSZ(nil)
+	require.Error(t, err)
+	_, err = (&cltypes.Deposit{Data: &cltypes.DepositData{}}).EncodeSSZ(nil)
+	require.Error(t, err)
+	_, err = (&cltypes.Deposit{Proof: solid.NewHashVector(cltypes.DepositProofLength)}).EncodeSSZ(nil)
+	require.Error(t, err)
+}
+
+func TestSignedVoluntaryExitVerifySignature(t *testing.T) {
+	// Exit of validator 504 in block 8322 of the phase0 random sanity test of the mainnet consensus spec tests.
+	pubkey := [48]byte(common.Hex2Bytes("a4dd03ac7dbcad3357f248116b313bfdcb2c04ba30ecfed848512b3995237894758d0b2222f23342174c12191add2120"))
+	// compute_domain(DOMAIN_VOLUNTARY_EXIT, 0x00000000, genesis_validators_root) of the test state.
+	domain := [32]byte(common.Hex2Bytes("04000000d18480117d2ffcc1387e1ba292853fd238ac2d23ecee20efee7610a8"))
+	signedExit := &cltypes.SignedVoluntaryExit{
+		VoluntaryExit: &cltypes.VoluntaryExit{Epoch: 260, ValidatorIndex: 504},
+		Signature:     [96]byte(common.Hex2Bytes("8fedc3077271b41f631d6062cc1cc8c8f074e486e9e692f198c5f82b94d2bb3b0fbf71cbac043cee94b56a7a06adf06d07bb7ecf06d8f699add17972ceb54b25e6021c3a2a727afd3370e960afbf345a75fddd2d221ba85a5f7b07e5607eec1e")),
+	}
+
+	valid, err := signedExit.VerifySignature(pubkey, domain)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	// Signature over a different domain.
+	valid, err = signedExit.VerifySignature(pubkey, [32]byte{5})
+	require.NoError(t, err)
+	require.False(t, valid)
+
+	// Signature of another exit.
+	signedExit.VoluntaryExit.ValidatorIndex = 503
+	valid, err = signedExit.VerifySignature(pubkey, domain)
+	require.NoError(t, err)
+	require.False(t, valid)
+	signedExit.VoluntaryExit.ValidatorIndex = 504
+
+	// Corrupted signature bytes.
+	signedExit.Signature[10] ^= 0xff
+	valid, _ = signedExit.VerifySignature(pubkey, domain)
+	require.False(t, valid)
+
+	_, err = (&cltypes.SignedVoluntaryExit{}).VerifySignature(pubkey, domain)
+	require.Error(t, err)
+}
+
+func TestDepositMessage(t *testing.T) {
+	depositData := &cltypes.DepositData{
+		PubKey:                common.Bytes48(common.Hex2Bytes("93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a")),
+		WithdrawalCredentials: common.HexToHash("0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"),
+		Amount:                32000000000,
+	}
+	message := depositData.Message()
+	root, err := message.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("0xd9755efb879422513b7f3d7f397249a03d930a1c728dff7ca5522a73057982c2"), common.Hash(root))
+
+	messageHash, err := depositData.MessageHash()
+	require.NoError(t, err)
+	require.Equal(t, root, messageHash)
+
+	testRoundTripSSZ(t, message, &cltypes.DepositMessage{})
+}
+
+func TestDepositDataVerifyDepositSignature(t *testing.T) {
+	privateKey, err := bls.NewPrivateKeyFromBytes(common.Hex2Bytes("3f8a5c1a25c2b3b0b9e1f1d6d1e3d8b8a7d4c5e6f7a8b9c0d1e2f3a4b5c6d7e8"))
+	require.NoError(t, err)
+	// compute_domain(DOMAIN_DEPOSIT, GENESIS_FORK_VERSION=0x00000000, ZERO_HASH) on mainnet.
+	domain := [32]byte(common.Hex2Bytes("03000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9"))
+
+	depositData := &cltypes.DepositData{
+		WithdrawalCredentials: common.HexToHash("0x00f50428677c60f997aadeab24aabf7fceaef491c96a52b463ae91f95611cf71"),
+		Amount:                32000000000,
+	}
+	copy(depositData.PubKey[:], bls.CompressPublicKey(privateKey.PublicKey()))
+	root, err := depositData.MessageHash()
+	require.NoError(t, err)
+	signingRoot := merkle_tree.SigningRoot(root, domain)
+	copy(depositData.Signature[:], privateKey.Sign(signingRoot[:]).Bytes())
+
+	valid, err := depositData.VerifyDepositSignature(domain)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	// The signature does not cover a mutated amount.
+	depositData.Amount = 1000000000
+	valid, err = depositData.VerifyDepositSignature(domain)
+	require.NoError(t, err)
+	require.False(t, valid)
+}
+
+func TestDepositDataGenerateProof(t *testing.T) {
+	depositData := &cltypes.DepositData{
+		PubKey:                [48]byte{1, 2, 3},
+		WithdrawalCredentials: [32]byte{4, 5, 6},
+		Amount:                32000000000,
+		Signature:             [96]byte{7, 8, 9},
+	}
+	root, err := depositData.HashSSZ()
+	require.NoError(t, err)
+	pubKeyLeaf, err := merkle_tree.BytesRoot(depositData.PubKey[:])
+	require.NoError(t, err)
+	signatureLeaf, err := merkle_tree.BytesRoot(depositData.Signature[:])
+	require.NoError(t, err)
+	leaves := [][32]byte{pubKeyLeaf, depositData.WithdrawalCredentials, merkle_tree.Uint64Root(depositData.Amount), signatureLeaf}
+
+	for i, leaf := range leaves {
+		proof, err := depositData.GenerateProof(i)
+		require.NoError(t, err)
+		require.Len(t, proof, 2)
+		require.True(t, merkle_tree.VerifyProof(root, leaf, proof, i))
+		// Proofs are bound to their position.
+		require.False(t, merkle_tree.VerifyProof(root, leaf, proof, i^1))
+	}
+
+	proof, err := depositData.GenerateProof(2)
+	require.NoError(t, err)
+	require.False(t, merkle_tree.VerifyProof(root, merkle_tree.Uint64Root(depositData.Amount+1), proof, 2))
+
+	_, err = depositData.GenerateProof(4)
+	require.Error(t, err)
+	_, err = depositData.GenerateProof(-1)
+	require.Error(t, err)
+}
+
+func TestDepositVerifyProof(t *testing.T) {
+	// Deposit 1 of a deposit tree with 3 deposits.
+	deposit := &cltypes.Deposit{
+		Proof: solid.NewHashVector(cltypes.DepositProofLength),
+		Data: &cltypes.DepositData{
+			PubKey:                [48]byte{2},
+			WithdrawalCredentials: [32]byte{0x11},
+			Amount:                32000000000,
+			Signature:             [96]byte{0x21},
+		},
+	}
+	leaf, err := deposit.Data.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("0x07641c79b1ade2cdeccb59448c9a7155076995144e82f238fcfeff8ee80dce50"), common.Hash(leaf))
+	deposit.Proof.Set(0, common.HexToHash("0x8ab07b35199401e1db386c21e38861a65e0986023869d29d7db66e0ae2c39d49"))
+	deposit.Proof.Set(1, common.HexToHash("0xcadbb0b7c4a4d847cd0d17bdaad6de1bd2a10ccfb01905a9c6b071edb8e76284"))
+	for i := 2; i < cltypes.DepositProofLength-1; i++ {
+		deposit.Proof.Set(i, merkle_tree.ZeroHashes[i])
+	}
+	deposit.Proof.Set(cltypes.DepositProofLength-1, common.Hash{3})
+	depositRoot := common.HexToHash("0xd9252cc1fec7667eaf412833fd2194119c0d7fd51de24e897cb203e29c3372de")
+
+	valid, err := deposit.VerifyProof(1, depositRoot)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	valid, err = deposit.VerifyProof(2, depositRoot)
+	require.NoError(t, err)
+	require.False(t, valid)
+
+	valid, err = deposit.VerifyProofWithDepth(clparams.MainnetBeaconConfig.DepositContractTreeDepth, 1, depositRoot)
+	require.NoError(t, err)
+	require.True(t, valid)
+	// The proof does not fit a tree of another depth.
+	_, err = deposit.VerifyProofWithDepth(31, 1, depositRoot)
+	require.Error(t, err)
+
+	deposit.Proof.Set(5, common.Hash{0xff})
+	valid, err = deposit.VerifyProof(1, depositRoot)
+	require.NoError(t, err)
+	require.False(t, valid)
+
+	_, err = (&cltypes.Deposit{}).VerifyProof(1, depositRoot)
+	require.Error(t, err)
+}
+
+func TestDepositProofBranch(t *testing.T) {
+	var branch [cltypes.DepositProofLength]common.Hash
+	for i := range branch {
+		branch[i] = common.Hash{byte(i), 0xaa}
+	}
+	data := &cltypes.DepositData{PubKey: [48]byte{1}, Amount: 32000000000}
+	deposit := &cltypes.Deposit{Proof: cltypes.NewDepositProof(branch), Data: data}
+	// The same deposit, with its proof set hash by hash.
+	expected := &cltypes.Deposit{Proof: solid.NewHashVector(cltypes.DepositProofLength), Data: data}
+	for i := range branch {
+		expected.Proof.Set(i, branch[i])
+	}
+
+	encoded, err := deposit.EncodeSSZ(nil)
+	require.NoError(t, err)
+	expectedEncoded, err := expected.EncodeSSZ(nil)
+	require.NoError(t, err)
+	require.Equal(t, expectedEncoded, encoded)
+	root, err := deposit.HashSSZ()
+	require.NoError(t, err)
+	expectedRoot, err := expected.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, expectedRoot, root)
+
+	decoded := &cltypes.Deposit{}
+	require.NoError(t, decoded.DecodeSSZ(encoded, 0))
+	decodedBranch, err := decoded.ProofBranch()
+	require.NoError(t, err)
+	require.Equal(t, branch, decodedBranch)
+
+	_, err = (&cltypes.Deposit{}).ProofBranch()
+	require.Error(t, err)
+	_, err = (&cltypes.Deposit{Proof: solid.NewHashVector(cltypes.DepositProofLength - 1)}).ProofBranch()
+	require.Error(t, err)
+}
+
+// testDepositsSSZ holds the 10 real deposits included in the block at slot 8322 of the beacon API test
+// data. Their proofs are against a deposit tree of 538 deposits.
+//
+//go:embed testdata/deposits_528.ssz
+var testDepositsSSZ []byte
+
+// testDepositsStartIndex is the index in the deposit tree of the first of the test deposits.
+const testDepositsStartIndex = 528
+
+func getTestDeposits() ([]*cltypes.Deposit, error) {
+	deposits := cltypes.NewDepositList()
+	if err := deposits.DecodeSSZ(testDepositsSSZ, 0); err != nil {
+		return nil, err
+	}
+	out := make([]*cltypes.Deposit, 0, deposits.Len())
+	deposits.Range(func(_ int, deposit *cltypes.Deposit, _ int) bool {
+		out = append(out, deposit)
+		return true
+	})
+	return out, nil
+}
+
+func TestTestDeposits(t *testing.T) {
+	deposits, err := getTestDeposits()
+	require.NoError(t, err)
+	require.Len(t, deposits, 10)
+	// compute_domain(DOMAIN_DEPOSIT, GENESIS_FORK_VERSION=0x00000000, ZERO_HASH) on mainnet.
+	domain := [32]byte(common.Hex2Bytes("03000000f5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a9"))
+	// Every proof leads to the same deposit root, the one of a tree of 538 deposits.
+	depositRoot := common.HexToHash("0x6d0697bf9d1223b0c2aaae0e73b9e03fdaae2c93d8e4340a88b3d26482d1c549")
+	for i, deposit := range deposits {
+		index := uint64(testDepositsStartIndex + i)
+		valid, err := deposit.VerifyProof(index, depositRoot)
+		require.NoError(t, err)
+		require.True(t, valid, "deposit %d", index)
+		valid, err = deposit.VerifyProof(index+1, depositRoot)
+		require.NoError(t, err)
+		require.False(t, valid, "deposit %d", index)
+		require.Equal(t, merkle_tree.Uint64Root(538), deposit.Proof.Get(cltypes.DepositProofLength-1))
+
+		valid, err = deposit.Data.VerifyDepositSignature(domain)
+		require.NoError(t, err)
+		require.True(t, valid, "deposit %d", index)
+	}
+}
+
+// TestDepositRootMixIn rebuilds the mainnet deposit root of 538 deposits from the branches of the test
+// deposits: the tree root has the deposit count mixed in, not the index of the last deposit.
+func TestDepositRootMixIn(t *testing.T) {
+	deposits, err := getTestDeposits()
+	require.NoError(t, err)
+	depositRoot := common.HexToHash("0x6d0697bf9d1223b0c2aaae0e73b9e03fdaae2c93d8e4340a88b3d26482d1c549")
+	for i, deposit := range deposits {
+		node, err := deposit.Data.HashSSZ()
+		require.NoError(t, err)
+		index := uint64(testDepositsStartIndex + i)
+		for height := 0; height < merkle_tree.DepositContractTreeDepth; height++ {
+			sibling := deposit.Proof.Get(height)
+			if index>>height&1 == 1 {
+				node = utils.Sha256(sibling[:], node[:])
+			} else {
+				node = utils.Sha256(node[:], sibling[:])
+			}
+		}
+		require.Equal(t, depositRoot, common.Hash(merkle_tree.MixInLength(node, 538)), "deposit %d", index)
+		require.NotEqual(t, depositRoot, common.Hash(merkle_tree.MixInLength(node, 537)), "deposit %d", index)
+	}
+}
+
+// depositDataJSON follows the DepositData schema of the beacon-APIs.
+const depositDataJSON = `{"pubkey":"0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a","withdrawal_credentials":"0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2","amount":"32000000000","signature":"0x1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505cc411d61252fb6cb3fa0017b679f8bb2305b26a285fa2737f175668d0dff91cc1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505"}`
+
+func TestDepositDataJson(t *testing.T) {
+	depositData := &cltypes.DepositData{
+		PubKey:                common.Bytes48(common.Hex2Bytes("93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a")),
+		WithdrawalCredentials: common.HexToHash("0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2"),
+		Amount:                32000000000,
+		Signature:             common.Bytes96(common.Hex2Bytes("1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505cc411d61252fb6cb3fa0017b679f8bb2305b26a285fa2737f175668d0dff91cc1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505")),
+	}
+	encoded, err := json.Marshal(depositData)
+	require.NoError(t, err)
+	require.Equal(t, depositDataJSON, string(encoded))
+
+	decoded := &cltypes.DepositData{}
+	require.NoError(t, json.Unmarshal([]byte(depositDataJSON), decoded))
+	require.Equal(t, depositData, decoded)
+
+	// Amounts above 2^53 must survive the round trip.
+	depositData.Amount = math.MaxUint64
+	encoded, err = json.Marshal(depositData)
+	require.NoError(t, err)
+	require.Contains(t, string(encoded), `"amount":"18446744073709551615"`)
+	require.NoError(t, json.Unmarshal(encoded, decoded))
+	require.Equal(t, depositData, decoded)
+}
+
+const signedVoluntaryExitJSON = `{"message":{"epoch":"1","validator_index":"1"},"signature":"0x1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505cc411d61252fb6cb3fa0017b679f8bb2305b26a285fa2737f175668d0dff91cc1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505"}`
+
+func TestSignedVoluntaryExitJson(t *testing.T) {
+	signedExit := &cltypes.SignedVoluntaryExit{
+		VoluntaryExit: &cltypes.VoluntaryExit{Epoch: 1, ValidatorIndex: 1},
+		Signature:     common.Bytes96(common.Hex2Bytes("1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505cc411d61252fb6cb3fa0017b679f8bb2305b26a285fa2737f175668d0dff91cc1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505")),
+	}
+	encoded, err := json.Marshal(signedExit)
+	require.NoError(t, err)
+	require.Equal(t, signedVoluntaryExitJSON, string(encoded))
+
+	decoded := &cltypes.SignedVoluntaryExit{}
+	require.NoError(t, json.Unmarshal(encoded, decoded))
+	require.Equal(t, signedExit, decoded)
+
+	_, err = json.Marshal(&cltypes.SignedVoluntaryExit{})
+	require.Error(t, err)
+}
+
+func TestEncodeSSZTo(t *testing.T) {
+	depositData := &cltypes.DepositData{
+		PubKey:                [48]byte{1, 2, 3},
+		WithdrawalCredentials: [32]byte{4, 5, 6},
+		Amount:                32000000000,
+		Signature:             [96]byte{7, 8, 9},
+	}
+	proof := solid.NewHashVector(cltypes.DepositProofLength)
+	for i := 0; i < cltypes.DepositProofLength; i++ {
+		proof.Set(i, common.Hash{byte(i)})
+	}
+	exit := &cltypes.VoluntaryExit{Epoch: 5, ValidatorIndex: 10}
+	committee := make([]common.Bytes48, cltypes.SyncCommitteeSize)
+	for i := range committee {
+		committee[i][0] = byte(i)
+	}
+
+	for _, obj := range []interface {
+		ssz2.SizedObjectSSZ
+		ssz2.EncoderTo
+	}{
+		depositData,
+		&cltypes.Deposit{Proof: proof, Data: depositData},
+		exit,
+		&cltypes.SignedVoluntaryExit{VoluntaryExit: exit, Signature: [96]byte{1}},
+		solid.NewSyncCommitteeFromParameters(committee, [48]byte{1}),
+	} {
+		expected, err := obj.EncodeSSZ(nil)
+		require.NoError(t, err)
+		var buf bytes.Buffer
+		n, err := obj.EncodeSSZTo(&buf)
+		require.NoError(t, err)
+		require.Equal(t, len(expected), n)
+		require.Equal(t, expected, buf.Bytes())
+	}
+
+	_, err := (&cltypes.SignedVoluntaryExit{}).EncodeSSZTo(io.Discard)
+	require.Error(t, err)
+}
+
+func TestDepositList(t *testing.T) {
+	for _, count := range []int{0, 1, cltypes.MaxDeposits} {
+		deposits := cltypes.NewDepositList()
+		leaves := make([][32]byte, 0, count)
+		for i := 0; i < count; i++ {
+			deposit := &cltypes.Deposit{
+				Proof: solid.NewHashVector(cltypes.DepositProofLength),
+				Data:  &cltypes.DepositData{PubKey: [48]byte{byte(i)}, Amount: 32000000000},
+			}
+			deposits.Append(deposit)
+			root, err := deposit.HashSSZ()
+			require.NoError(t, err)
+			leaves = append(leaves, root)
+		}
+		expected, err := merkle_tree.ListRoot(leaves, cltypes.MaxDeposits)
+		require.NoError(t, err)
+		root, err := deposits.HashSSZ()
+		require.NoError(t, err)
+		require.Equal(t, expected, root)
+
+		encoded, err := deposits.EncodeSSZ(nil)
+		require.NoError(t, err)
+		require.Len(t, encoded, count*1240)
+		decoded := cltypes.NewDepositList()
+		require.NoError(t, decoded.DecodeSSZ(encoded, 0))
+		require.Equal(t, count, decoded.Len())
+		decodedRoot, err := decoded.HashSSZ()
+		require.NoError(t, err)
+		require.Equal(t, expected, decodedRoot)
+	}
+
+	// One deposit over the limit.
+	require.Error(t, cltypes.NewDepositList().DecodeSSZ(make([]byte, (cltypes.MaxDeposits+1)*1240), 0))
+}
+
+func TestDepositsListObjectSSZRoot(t *testing.T) {
+	deposits := make([]*cltypes.Deposit, 3)
+	items := make([]ssz.HashableSSZ, len(deposits))
+	leaves := make([][32]byte, len(deposits))
+	for i := range deposits {
+		deposits[i] = &cltypes.Deposit{
+			Proof: solid.NewHashVector(cltypes.DepositProofLength),
+			Data:  &cltypes.DepositData{PubKey: [48]byte{byte(i)}, Amount: uint64(i+1) * 1000000000},
+		}
+		items[i] = deposits[i]
+		var err error
+		leaves[i], err = deposits[i].HashSSZ()
+		require.NoError(t, err)
+	}
+	expected, err := merkle_tree.ListRoot(leaves, cltypes.MaxDeposits)
+	require.NoError(t, err)
+
+	// Through the interface, as generic code holding mixed objects would.
+	root, err := merkle_tree.ListObjectSSZRoot(items, cltypes.MaxDeposits)
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+	root, err = merkle_tree.ListObjectSSZRoot(deposits, cltypes.MaxDeposits)
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+
+	_, err = merkle_tree.ListObjectSSZRoot(items, 2)
+	require.Error(t, err)
+}
+
+func TestSignedVoluntaryExitList(t *testing.T) {
+	expectedRoots := map[int]string{
+		0:                         "0x792930bbd5baac43bcc798ee49aa8185ef76bb3b44ba62b91d86ae569e4bb535",
+		1:                         "0xcbe646fa3022d71f57756b5b91dabf0218d9e6e85e5477c18caa84de8bbba76a",
+		cltypes.MaxVoluntaryExits: "0xe13bbc00779ae54170441502b4285b7211962941f49cdef4ee4a3ad513db1da5",
+	}
+	for count, expectedRoot := range expectedRoots {
+		exits := cltypes.NewSignedVoluntaryExitList()
+		for i := 0; i < count; i++ {
+			exits.Append(&cltypes.SignedVoluntaryExit{
+				VoluntaryExit: &cltypes.VoluntaryExit{Epoch: uint64(i), ValidatorIndex: uint64(i * 3)},
+				Signature:     common.Bytes96{byte(i)},
+			})
+		}
+		root, err := exits.HashSSZ()
+		require.NoError(t, err)
+		require.Equal(t, common.HexToHash(expectedRoot), common.Hash(root))
+
+		// The elements are fixed size, so they are encoded back to back without offsets.
+		encoded, err := exits.EncodeSSZ(nil)
+		require.NoError(t, err)
+		require.Len(t, encoded, count*112)
+		decoded := cltypes.NewSignedVoluntaryExitList()
+		require.NoError(t, decoded.DecodeSSZ(encoded, 0))
+		require.Equal(t, count, decoded.Len())
+		decodedRoot, err := decoded.HashSSZ()
+		require.NoError(t, err)
+		require.Equal(t, root, decodedRoot)
+	}
+
+	// One exit over the limit, and a truncated exit.
+	require.Error(t, cltypes.NewSignedVoluntaryExitList().DecodeSSZ(make([]byte, (cltypes.MaxVoluntaryExits+1)*112), 0))
+	require.Error(t, cltypes.NewSignedVoluntaryExitList().DecodeSSZ(make([]byte, 111), 0))
+}
+
+func TestEncodingSizeSSZ(t *testing.T) {
+	depositData := &cltypes.DepositData{Amount: 32000000000}
+	tests := []ssz2.SizedObjectSSZ{
+		depositData.Message(),
+		depositData,
+		&cltypes.Deposit{Proof: solid.NewHashVector(cltypes.DepositProofLength), Data: depositData},
+		&cltypes.VoluntaryExit{Epoch: 1},
+		&cltypes.SignedVoluntaryExit{VoluntaryExit: &cltypes.VoluntaryExit{Epoch: 1}},
+	}
+	for _, obj := range tests {
+		encoded, err := obj.EncodeSSZ(nil)
+		require.NoError(t, err)
+		require.Len(t, encoded, obj.EncodingSizeSSZ(), "%T", obj)
+	}
+	require.Equal(t, 1240, (&cltypes.Deposit{}).EncodingSizeSSZ())
+}
+
+func BenchmarkDepositDataEncodeSSZ(b *testing.B) {
+	d := &cltypes.DepositData{PubKey: [48]byte{1}, WithdrawalCredentials: [32]byte{2}, Amount: 32000000000, Signature: [96]byte{3}}
+	buf := make([]byte, 0, d.EncodingSizeSSZ())
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := d.EncodeSSZ(buf[:0]); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDepositDataDecodeSSZ(b *testing.B) {
+	d := &cltypes.DepositData{PubKey: [48]byte{1}, WithdrawalCredentials: [32]byte{2}, Amount: 32000000000, Signature: [96]byte{3}}
+	encoded, err := d.EncodeSSZ(nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	decoded := new(cltypes.DepositData)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := decoded.DecodeSSZ(encoded, 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkSignedVoluntaryExitDecodeSSZ(b *testing.B) {
+	exit := &cltypes.SignedVoluntaryExit{VoluntaryExit: &cltypes.VoluntaryExit{Epoch: 194048, ValidatorIndex: 21}, Signature: [96]byte{1}}
+	encoded, err := exit.EncodeSSZ(nil)
+	if err != nil {
+		b.Fatal(err)
+	}
+	decoded := new(cltypes.SignedVoluntaryExit)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := decoded.DecodeSSZ(encoded, 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func testDeposits(n int) []*cltypes.Deposit {
+	deposits := make([]*cltypes.Deposit, n)
+	for i := range deposits {
+		proof := solid.NewHashVector(cltypes.DepositProofLength)
+		for j := 0; j < cltypes.DepositProofLength; j++ {
+			proof.Set(j, common.Hash{byte(i), byte(i >> 8), byte(j)})
+		}
+		deposits[i] = &cltypes.Deposit{
+			Proof: proof,
+			Data:  &cltypes.DepositData{PubKey: [48]byte{byte(i), byte(i >> 8)}, Amount: uint64(i), Signature: [96]byte{byte(i)}},
+		}
+	}
+	return deposits
+}
+
+func TestDepositRoots(t *testing.T) {
+	for _, n := range []int{0, 1, 7, 100} {
+		deposits := testDeposits(n)
+		roots, err := cltypes.DepositRoots(deposits)
+		require.NoError(t, err)
+		require.Len(t, roots, n)
+		for i, deposit := range deposits {
+			expected, err := deposit.HashSSZ()
+			require.NoError(t, err)
+			require.Equal(t, expected, roots[i], "deposit %d of %d", i, n)
+		}
+	}
+	// A zero deposit data goes through the zero root as well.
+	deposits := testDeposits(3)
+	deposits[1].Data = &cltypes.DepositData{}
+	roots, err := cltypes.DepositRoots(deposits)
+	require.NoError(t, err)
+	expected, err := deposits[1].HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, expected, roots[1])
+
+	deposits[2] = nil
+	_, err = cltypes.DepositRoots(deposits)
+	require.Error(t, err)
+	_, err = cltypes.DepositRoots([]*cltypes.Deposit{{Proof: solid.NewHashVector(2), Data: &cltypes.DepositData{}}})
+	require.Error(t, err)
+}
+
+func BenchmarkDepositRoots(b *testing.B) {
+	deposits := testDeposits(cltypes.MaxDeposits * 64)
+	b.Run("serial", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for _, deposit := range deposits {
+				if _, err := deposit.HashSSZ(); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("batch", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			if _, err := cltypes.DepositRoots(deposits); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -18,10 +18,10 @@ type Withdrawal struct {
 }
 
 func (obj *Withdrawal) EncodeSSZ(buf []byte) ([]byte, error) {
-	buf = append(buf, ssz.Uint64SSZ(obj.Index)...)
-	buf = append(buf, ssz.Uint64SSZ(obj.Validator)...)
+	buf = ssz.AppendUint64SSZ(buf, obj.Index)
+	buf = ssz.AppendUint64SSZ(buf, obj.Validator)
 	buf = append(buf, obj.Address[:]...)
-	buf = append(buf, ssz.Uint64SSZ(obj.Amount)...)
+	buf = ssz.AppendUint64SSZ(buf, obj.Amount)
 	return buf, nil
 }
 
@@ -161,9 +161,7 @@ func forkList(schedule map[libcommon.Bytes4]uint64) (f []fork) {
 }
 
 func ComputeForkDigestForVersion(currentVersion [4]byte, genesisValidatorsRoot [32]byte) (digest [4]byte, err error) {
-	var currentVersion32 libcommon.Hash
-	copy(currentVersion32[:], currentVersion[:])
-	dataRoot := utils.Sha256(currentVersion32[:], genesisValidatorsRoot[:])
+	dataRoot := cltypes.ComputeForkDataRoot(currentVersion, genesisValidatorsRoot)
 	// copy first four bytes to output
 	copy(digest[:], dataRoot[:4])
 	return
@@ -225,9 +223,7 @@ func ComputeDomain(
 	currentVersion [4]byte,
 	genesisValidatorsRoot [32]byte,
 ) ([]byte, error) {
-	var currentVersion32 libcommon.Hash
-	copy(currentVersion32[:], currentVersion[:])
-	forkDataRoot := utils.Sha256(currentVersion32[:], genesisValidatorsRoot[:])
+	forkDataRoot := cltypes.ComputeForkDataRoot(currentVersion, genesisValidatorsRoot)
 	return append(domainType, forkDataRoot[:28]...), nil
 }
 
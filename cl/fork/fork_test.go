@@ -20,6 +20,7 @@ import (
 
 	"github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
 	"github.com/ledgerwatch/erigon/cl/utils"
 )
 
@@ -145,3 +146,22 @@ func TestMainnetComputeDomain(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, expectedResult, result)
 }
+
+func TestMainnetComputeDomainProposerAndVoluntaryExit(t *testing.T) {
+	genesisValidatorsRoot := common.HexToHash("0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95")
+	cfg := clparams.MainnetBeaconConfig
+
+	// DOMAIN_BEACON_PROPOSER at the altair fork.
+	proposerDomain, err := ComputeDomain(cfg.DomainBeaconProposer[:], utils.Uint32ToBytes4(uint32(cfg.AltairForkVersion)), genesisValidatorsRoot)
+	require.NoError(t, err)
+	require.Equal(t, common.Hex2Bytes("00000000afcaaba0efab1ca832a15152469bb09bb84641c405171dfa2d3fb45f"), proposerDomain)
+
+	// DOMAIN_VOLUNTARY_EXIT, pinned to the capella fork since deneb.
+	exitDomain, err := ComputeDomain(cfg.DomainVoluntaryExit[:], utils.Uint32ToBytes4(uint32(cfg.CapellaForkVersion)), genesisValidatorsRoot)
+	require.NoError(t, err)
+	require.Equal(t, common.Hex2Bytes("04000000bba4da96354c9f25476cf1bc69bf583a7f9e0af049305b62de676640"), exitDomain)
+
+	signingRoot, err := ComputeSigningRoot(&cltypes.VoluntaryExit{Epoch: 194048, ValidatorIndex: 21}, exitDomain)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("0x6bb0170baa017874e18715b63a9092780dda86424f0d0d6788bd41d91b4f29be"), common.Hash(signingRoot))
+}
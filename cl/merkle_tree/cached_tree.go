@@ -0,0 +1,140 @@
+package merkle_tree
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/prysmaticlabs/gohashtree"
+
+	"github.com/ledgerwatch/erigon/cl/utils"
+)
+
+// CachedTree is a merkle tree of 32-byte leaves padded to the next power of two of a limit, which keeps
+// its intermediate layers so that after some leaves change only the paths from them to the root are
+// rehashed. The leaves are not copied: callers update them in place and mark them dirty.
+// Its root is the one of VectorRoot(leaves, limit); lists mix their length in on top of it.
+type CachedTree struct {
+	depth uint8
+	limit uint64
+	// layers[0] is the leaves, layers[h] the nodes at height h up to the first layer of one node.
+	layers [][][32]byte
+	dirty  []uint64
+	// dirtyBits has the bit of every leaf in dirty set, so that a leaf is queued at most once.
+	dirtyBits []uint64
+	// rebuild is set by Reset: every layer is recomputed on the next Root.
+	rebuild bool
+	root    [32]byte
+}
+
+func NewCachedTree(limit uint64) *CachedTree {
+	return &CachedTree{
+		depth:   GetDepth(NextPowerOfTwo(limit)),
+		limit:   limit,
+		rebuild: true,
+	}
+}
+
+// Reset replaces the leaves of the tree. The whole tree is rehashed on the next call to Root.
+func (t *CachedTree) Reset(leaves [][32]byte) error {
+	if uint64(len(leaves)) > t.limit {
+		return fmt.Errorf("cached tree has %d leaves, more than its limit %d", len(leaves), t.limit)
+	}
+	t.layers = t.layers[:0]
+	t.layers = append(t.layers, leaves)
+	for layer := leaves; len(layer) > 1; {
+		layer = make([][32]byte, (len(layer)+1)/2)
+		t.layers = append(t.layers, layer)
+	}
+	t.dirty = t.dirty[:0]
+	words := (len(leaves) + 63) / 64
+	if cap(t.dirtyBits) < words {
+		t.dirtyBits = make([]uint64, words)
+	}
+	t.dirtyBits = t.dirtyBits[:words]
+	for i := range t.dirtyBits {
+		t.dirtyBits[i] = 0
+	}
+	t.rebuild = true
+	return nil
+}
+
+// MarkDirty records that the leaf at index changed since the last call to Root.
+func (t *CachedTree) MarkDirty(index uint64) {
+	if len(t.layers) == 0 || index >= uint64(len(t.layers[0])) {
+		panic("CachedTree -- MarkDirty: out of bounds")
+	}
+	word, bit := index/64, uint64(1)<<(index%64)
+	if t.dirtyBits[word]&bit != 0 {
+		return
+	}
+	t.dirtyBits[word] |= bit
+	t.dirty = append(t.dirty, index)
+}
+
+// Root returns the root of the tree, rehashing the paths of the leaves marked dirty since the last call.
+func (t *CachedTree) Root() ([32]byte, error) {
+	for _, index := range t.dirty {
+		t.dirtyBits[index/64] &^= uint64(1) << (index % 64)
+	}
+	if t.rebuild {
+		if err := t.computeLayers(); err != nil {
+			return [32]byte{}, err
+		}
+	} else if len(t.dirty) > 0 {
+		t.computeDirtyPaths()
+	} else {
+		return t.root, nil
+	}
+	t.dirty = t.dirty[:0]
+	t.rebuild = false
+
+	if len(t.layers) == 0 || len(t.layers[0]) == 0 {
+		t.root = ZeroHashes[t.depth]
+		return t.root, nil
+	}
+	// Climb from the top cached layer to the depth of the tree over zero subtrees.
+	node := t.layers[len(t.layers)-1][0]
+	for height := len(t.layers) - 1; height < int(t.depth); height++ {
+		node = utils.Sha256(node[:], ZeroHashes[height][:])
+	}
+	t.root = node
+	return t.root, nil
+}
+
+func (t *CachedTree) computeLayers() error {
+	for height := 0; height+1 < len(t.layers); height++ {
+		children, parents := t.layers[height], t.layers[height+1]
+		even := len(children) &^ 1
+		if err := gohashtree.Hash(parents[:even/2], children[:even]); err != nil {
+			return err
+		}
+		if even < len(children) {
+			parents[even/2] = utils.Sha256(children[even][:], ZeroHashes[height][:])
+		}
+	}
+	return nil
+}
+
+func (t *CachedTree) computeDirtyPaths() {
+	indices := t.dirty
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	for height := 0; height+1 < len(t.layers); height++ {
+		children, parents := t.layers[height], t.layers[height+1]
+		// Parents of sorted indices are sorted: deduplicate them in place.
+		parentsCount := 0
+		for _, index := range indices {
+			parent := index / 2
+			if parentsCount > 0 && indices[parentsCount-1] == parent {
+				continue
+			}
+			left, right := children[2*parent], ZeroHashes[height]
+			if 2*parent+1 < uint64(len(children)) {
+				right = children[2*parent+1]
+			}
+			parents[parent] = utils.Sha256(left[:], right[:])
+			indices[parentsCount] = parent
+			parentsCount++
+		}
+		indices = indices[:parentsCount]
+	}
+}
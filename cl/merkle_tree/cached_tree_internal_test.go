@@ -0,0 +1,39 @@
+package merkle_tree
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedTreeMarkDirtyOnce(t *testing.T) {
+	leaves := make([][32]byte, 100)
+	tree := NewCachedTree(uint64(len(leaves)))
+	require.NoError(t, tree.Reset(leaves))
+	_, err := tree.Root()
+	require.NoError(t, err)
+
+	// A leaf set many times between two roots is rehashed once.
+	for i := 0; i < 1000; i++ {
+		leaves[70] = [32]byte{byte(i)}
+		tree.MarkDirty(70)
+		tree.MarkDirty(uint64(i % 3))
+	}
+	require.Len(t, tree.dirty, 4)
+	root, err := tree.Root()
+	require.NoError(t, err)
+	expected, err := VectorRoot(leaves, uint64(len(leaves)))
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+	require.Empty(t, tree.dirty)
+
+	// The leaves are marked again after a root.
+	leaves[70] = [32]byte{1}
+	tree.MarkDirty(70)
+	require.Len(t, tree.dirty, 1)
+	root, err = tree.Root()
+	require.NoError(t, err)
+	expected, err = VectorRoot(leaves, uint64(len(leaves)))
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+}
@@ -0,0 +1,111 @@
+package merkle_tree_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cl/merkle_tree"
+)
+
+func randomLeaves(rng *rand.Rand, n int) [][32]byte {
+	leaves := make([][32]byte, n)
+	for i := range leaves {
+		rng.Read(leaves[i][:])
+	}
+	return leaves
+}
+
+func TestCachedTreeRandomMutations(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+	for _, test := range []struct {
+		leaves int
+		limit  uint64
+	}{
+		{0, 16},
+		{1, 1},
+		{1, 1 << 40},
+		{5, 8},
+		{8, 8},
+		{13, 100},
+		{1000, 1 << 20},
+	} {
+		tree := merkle_tree.NewCachedTree(test.limit)
+		leaves := randomLeaves(rng, test.leaves)
+		require.NoError(t, tree.Reset(leaves))
+		for round := 0; round < 20; round++ {
+			if len(leaves) > 0 {
+				for i := 0; i < 1+rng.Intn(10); i++ {
+					index := rng.Intn(len(leaves))
+					rng.Read(leaves[index][:])
+					tree.MarkDirty(uint64(index))
+				}
+			}
+			root, err := tree.Root()
+			require.NoError(t, err)
+			expected, err := merkle_tree.VectorRoot(leaves, test.limit)
+			require.NoError(t, err)
+			require.Equal(t, expected, root, "%d leaves, limit %d, round %d", test.leaves, test.limit, round)
+		}
+	}
+}
+
+func TestCachedTreeReset(t *testing.T) {
+	tree := merkle_tree.NewCachedTree(64)
+	root, err := tree.Root()
+	require.NoError(t, err)
+	require.Equal(t, merkle_tree.ZeroHashes[6], root)
+
+	rng := rand.New(rand.NewSource(1))
+	for _, n := range []int{3, 64, 0, 17} {
+		leaves := randomLeaves(rng, n)
+		require.NoError(t, tree.Reset(leaves))
+		root, err := tree.Root()
+		require.NoError(t, err)
+		expected, err := merkle_tree.VectorRoot(leaves, 64)
+		require.NoError(t, err)
+		require.Equal(t, expected, root)
+	}
+	require.Error(t, tree.Reset(make([][32]byte, 65)))
+	require.Panics(t, func() { tree.MarkDirty(17) })
+}
+
+const benchmarkCachedTreeLeaves = 1 << 20
+
+func BenchmarkCachedTreeFewDirtyLeaves(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	leaves := randomLeaves(rng, benchmarkCachedTreeLeaves)
+	tree := merkle_tree.NewCachedTree(1 << 40)
+	if err := tree.Reset(leaves); err != nil {
+		b.Fatal(err)
+	}
+	if _, err := tree.Root(); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 16; j++ {
+			index := rng.Intn(len(leaves))
+			leaves[index][0]++
+			tree.MarkDirty(uint64(index))
+		}
+		if _, err := tree.Root(); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkCachedTreeFromScratch(b *testing.B) {
+	rng := rand.New(rand.NewSource(1))
+	leaves := randomLeaves(rng, benchmarkCachedTreeLeaves)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for j := 0; j < 16; j++ {
+			leaves[rng.Intn(len(leaves))][0]++
+		}
+		if _, err := merkle_tree.VectorRoot(leaves, 1<<40); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
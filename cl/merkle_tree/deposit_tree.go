@@ -0,0 +1,100 @@
+package merkle_tree
+
+import (
+	"fmt"
+
+	"github.com/prysmaticlabs/gohashtree"
+
+	"github.com/ledgerwatch/erigon/cl/utils"
+)
+
+// DepositContractTreeDepth is the depth of the deposit contract merkle tree.
+const DepositContractTreeDepth = 32
+
+// DepositTree is the append-only merkle tree of the deposit contract. Like the contract it keeps the left
+// branch so that the deposit root is available after each insertion in O(depth), it also keeps the leaves
+// to build the inclusion proof of any deposit.
+type DepositTree struct {
+	leaves [][32]byte
+	branch [DepositContractTreeDepth][32]byte
+}
+
+func NewDepositTree() *DepositTree {
+	return &DepositTree{}
+}
+
+// DepositCount returns the number of deposits inserted in the tree.
+func (t *DepositTree) DepositCount() uint64 {
+	return uint64(len(t.leaves))
+}
+
+// Insert appends the root of a deposit data to the tree.
+func (t *DepositTree) Insert(leaf [32]byte) {
+	t.leaves = append(t.leaves, leaf)
+	size := uint64(len(t.leaves))
+	node := leaf
+	for height := 0; height < DepositContractTreeDepth; height++ {
+		if size&1 == 1 {
+			t.branch[height] = node
+			return
+		}
+		node = utils.Sha256(t.branch[height][:], node[:])
+		size /= 2
+	}
+}
+
+// Root returns the deposit root, as returned by the deposit contract: the root of the tree with the deposit
+// count mixed in.
+func (t *DepositTree) Root() [32]byte {
+	var node [32]byte
+	size := t.DepositCount()
+	for height := 0; height < DepositContractTreeDepth; height++ {
+		if size&1 == 1 {
+			node = utils.Sha256(t.branch[height][:], node[:])
+		} else {
+			node = utils.Sha256(node[:], ZeroHashes[height][:])
+		}
+		size /= 2
+	}
+	return MixInLength(node, t.DepositCount())
+}
+
+// RootWithCount returns the deposit root the contract returned when it held the first count deposits, as
+// found in the eth1 data of a block voting for an older eth1 block. The count is mixed in at the top of the
+// tree as a little-endian uint64 padded to 32 bytes, the count of the deposits and not the index of the last.
+func (t *DepositTree) RootWithCount(count uint64) ([32]byte, error) {
+	if count > t.DepositCount() {
+		return [32]byte{}, fmt.Errorf("deposit count %d exceeds the %d deposits of the tree", count, t.DepositCount())
+	}
+	if count == t.DepositCount() {
+		return t.Root(), nil
+	}
+	return ListRoot(t.leaves[:count], 1<<DepositContractTreeDepth)
+}
+
+// Proof returns the inclusion proof of the deposit at index against the current deposit root: the 32
+// siblings from the leaf up, followed by the deposit count, as in the deposits of a beacon block.
+func (t *DepositTree) Proof(index uint64) ([][32]byte, error) {
+	if index >= t.DepositCount() {
+		return nil, fmt.Errorf("deposit index %d out of range for %d deposits", index, t.DepositCount())
+	}
+	proof := make([][32]byte, 0, DepositContractTreeDepth+1)
+	layer := make([][32]byte, len(t.leaves), len(t.leaves)+1)
+	copy(layer, t.leaves)
+	for height := 0; height < DepositContractTreeDepth; height++ {
+		if sibling := index ^ 1; sibling < uint64(len(layer)) {
+			proof = append(proof, layer[sibling])
+		} else {
+			proof = append(proof, ZeroHashes[height])
+		}
+		if len(layer)%2 == 1 {
+			layer = append(layer, ZeroHashes[height])
+		}
+		if err := gohashtree.Hash(layer, layer); err != nil {
+			return nil, err
+		}
+		layer = layer[:len(layer)/2]
+		index /= 2
+	}
+	return append(proof, Uint64Root(t.DepositCount())), nil
+}
@@ -0,0 +1,110 @@
+package merkle_tree_test
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cl/merkle_tree"
+	"github.com/ledgerwatch/erigon/cl/utils"
+)
+
+func TestDepositTreeRoot(t *testing.T) {
+	tree := merkle_tree.NewDepositTree()
+	// The root of the deposit contract before any deposit.
+	require.Equal(t, common.HexToHash("0xd70a234731285c6804c2a4f56711ddb8c82c99740f207854891028af34e27e5e"), common.Hash(tree.Root()))
+
+	expected := []common.Hash{
+		common.HexToHash("0x4ddbc789b588afc571fe5047656e6c2d239a769407379820b700c7c5a00af4f6"),
+		common.HexToHash("0x709d940d7cdb8334b6f8f829018d9b3e2479b9988a9a903e17d25b8c07dc50ae"),
+		common.HexToHash("0x75d097f35e94b5937339aca95d84f34faf62eb9bc4e76ecba0c7b35d23a71831"),
+		common.HexToHash("0xafe6e31295a43a5867e7527b035d06a8b2b311704e90291fd4fe56316a47ab5d"),
+		common.HexToHash("0x4d1d41599c154b4aa778fa58f161f97059ee90634621d220a61ea079a2421b59"),
+	}
+	for i, root := range expected {
+		var leaf [32]byte
+		for j := range leaf {
+			leaf[j] = byte(i + 1)
+		}
+		tree.Insert(leaf)
+		require.Equal(t, uint64(i+1), tree.DepositCount())
+		require.Equal(t, root, common.Hash(tree.Root()), "after %d deposits", i+1)
+	}
+}
+
+func TestDepositTreeMatchesListRoot(t *testing.T) {
+	tree := merkle_tree.NewDepositTree()
+	leaves := testLeaves(70)
+	for i, leaf := range leaves {
+		tree.Insert(leaf)
+		// The deposit root is the root of a List[DepositData, 2**32].
+		expected, err := merkle_tree.ListRoot(leaves[:i+1], 1<<merkle_tree.DepositContractTreeDepth)
+		require.NoError(t, err)
+		require.Equal(t, expected, tree.Root(), "after %d deposits", i+1)
+	}
+}
+
+func TestDepositTreeProof(t *testing.T) {
+	tree := merkle_tree.NewDepositTree()
+	leaves := testLeaves(37)
+	for _, leaf := range leaves {
+		tree.Insert(leaf)
+	}
+	root := tree.Root()
+	for i, leaf := range leaves {
+		proof, err := tree.Proof(uint64(i))
+		require.NoError(t, err)
+		require.Len(t, proof, merkle_tree.DepositContractTreeDepth+1)
+		branch := make([]common.Hash, len(proof))
+		for j := range proof {
+			branch[j] = proof[j]
+		}
+		require.True(t, utils.IsValidMerkleBranch(leaf, branch, merkle_tree.DepositContractTreeDepth+1, uint64(i), root), "deposit %d", i)
+		require.False(t, utils.IsValidMerkleBranch(leaf, branch, merkle_tree.DepositContractTreeDepth+1, uint64(i+1), root), "deposit %d", i)
+	}
+	require.Equal(t, testLeaves(37), leaves)
+
+	_, err := tree.Proof(37)
+	require.Error(t, err)
+	_, err = merkle_tree.NewDepositTree().Proof(0)
+	require.Error(t, err)
+}
+
+func TestDepositTreeRootWithCount(t *testing.T) {
+	tree := merkle_tree.NewDepositTree()
+	leaves := testLeaves(40)
+	roots := [][32]byte{tree.Root()}
+	for _, leaf := range leaves {
+		tree.Insert(leaf)
+		roots = append(roots, tree.Root())
+	}
+	for count, expected := range roots {
+		root, err := tree.RootWithCount(uint64(count))
+		require.NoError(t, err)
+		require.Equal(t, expected, root, "%d deposits", count)
+
+		// The count is mixed in as a little-endian uint64 in a 32-byte chunk.
+		base, err := merkle_tree.VectorRoot(leaves[:count], 1<<merkle_tree.DepositContractTreeDepth)
+		require.NoError(t, err)
+		var countChunk [32]byte
+		binary.LittleEndian.PutUint64(countChunk[:], uint64(count))
+		require.Equal(t, utils.Sha256(base[:], countChunk[:]), root, "%d deposits", count)
+	}
+	_, err := tree.RootWithCount(41)
+	require.Error(t, err)
+}
+
+func BenchmarkDepositTreeInsert(b *testing.B) {
+	leaves := testLeaves(1024)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		tree := merkle_tree.NewDepositTree()
+		for _, leaf := range leaves {
+			tree.Insert(leaf)
+		}
+		tree.Root()
+	}
+}
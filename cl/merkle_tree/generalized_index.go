@@ -0,0 +1,44 @@
+package merkle_tree
+
+import (
+	"fmt"
+	"math/bits"
+)
+
+// GeneralizedIndex returns the generalized index of the node at the given position of the given depth:
+// the root has generalized index 1 and the children of node i are 2i and 2i+1.
+func GeneralizedIndex(depth, index uint64) uint64 {
+	return 1<<depth + index
+}
+
+// Concat returns the generalized index of a path through nested trees, each index being relative to
+// the root of the subtree pointed to by the previous one, e.g. Concat(52, 3) is the root of the
+// finalized checkpoint of an altair BeaconState. Generalized indices start at 1: an error is returned for
+// an index of 0, or when the resulting path is too deep for a uint64.
+func Concat(indices ...uint64) (uint64, error) {
+	out := uint64(1)
+	for _, index := range indices {
+		if index == 0 {
+			return 0, fmt.Errorf("generalized index 0 does not exist")
+		}
+		depth := bits.Len64(index) - 1
+		if bits.Len64(out)-1+depth > 63 {
+			return 0, fmt.Errorf("concatenated generalized index is deeper than 63")
+		}
+		out = out<<depth | (index - 1<<depth)
+	}
+	return out, nil
+}
+
+// GetPathIndices returns the generalized indices of the siblings of the nodes on the path from gindex
+// to the root, from the bottom up. These are the nodes making up the merkle proof of gindex.
+func GetPathIndices(gindex uint64) []uint64 {
+	if gindex <= 1 {
+		return nil
+	}
+	out := make([]uint64, 0, bits.Len64(gindex)-1)
+	for ; gindex > 1; gindex /= 2 {
+		out = append(out, gindex^1)
+	}
+	return out
+}
@@ -0,0 +1,57 @@
+package merkle_tree_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cl/merkle_tree"
+)
+
+func TestGeneralizedIndex(t *testing.T) {
+	require.Equal(t, uint64(1), merkle_tree.GeneralizedIndex(0, 0))
+	require.Equal(t, uint64(3), merkle_tree.GeneralizedIndex(1, 1))
+	// The altair BeaconState has 24 fields, so depth 5, and finalized_checkpoint is its field 20.
+	require.Equal(t, uint64(52), merkle_tree.GeneralizedIndex(5, 20))
+	// current_sync_committee and next_sync_committee are fields 22 and 23.
+	require.Equal(t, uint64(54), merkle_tree.GeneralizedIndex(5, 22))
+	require.Equal(t, uint64(55), merkle_tree.GeneralizedIndex(5, 23))
+	// execution_payload is field 9 of the bellatrix BeaconBlockBody, depth 4.
+	require.Equal(t, uint64(25), merkle_tree.GeneralizedIndex(4, 9))
+}
+
+func TestConcat(t *testing.T) {
+	concat := func(indices ...uint64) uint64 {
+		out, err := merkle_tree.Concat(indices...)
+		require.NoError(t, err)
+		return out
+	}
+	// FINALIZED_ROOT_GINDEX of the altair light client spec: the root of the finalized checkpoint.
+	require.Equal(t, uint64(105), concat(merkle_tree.GeneralizedIndex(5, 20), merkle_tree.GeneralizedIndex(1, 1)))
+	require.Equal(t, uint64(1), concat())
+	require.Equal(t, uint64(52), concat(52))
+	require.Equal(t, uint64(52), concat(1, 52, 1))
+	require.Equal(t, concat(concat(6, 5), 7), concat(6, concat(5, 7)))
+	require.Equal(t, uint64(1)<<63, concat(1<<32, 1<<31))
+
+	_, err := merkle_tree.Concat(52, 0)
+	require.Error(t, err)
+	_, err = merkle_tree.Concat(1<<32, 1<<32)
+	require.Error(t, err)
+}
+
+func TestGetPathIndices(t *testing.T) {
+	require.Equal(t, []uint64{104, 53, 27, 12, 7, 2}, merkle_tree.GetPathIndices(105))
+	require.Equal(t, []uint64{55, 26, 12, 7, 2}, merkle_tree.GetPathIndices(54))
+	require.Equal(t, []uint64{2}, merkle_tree.GetPathIndices(3))
+	require.Empty(t, merkle_tree.GetPathIndices(1))
+
+	// The nodes at the path indices of a leaf are its merkle proof.
+	leaves := testLeaves(32)
+	root, err := merkle_tree.VectorRoot(leaves, 32)
+	require.NoError(t, err)
+	proof, err := merkle_tree.MultiProof(leaves, []uint64{merkle_tree.GeneralizedIndex(5, 20)})
+	require.NoError(t, err)
+	require.Len(t, proof, len(merkle_tree.GetPathIndices(52)))
+	require.True(t, merkle_tree.VerifyProof(root, leaves[20], proof, 20))
+}
@@ -4,12 +4,34 @@ import (
 	"sync"
 
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
-	"github.com/ledgerwatch/erigon/cl/utils"
 	"github.com/prysmaticlabs/gohashtree"
 )
 
 var globalHasher *merkleHasher
 
+// leavesPool holds scratch layers for the functions that must not hash the caller's leaves in place.
+// SHA-256 hashers themselves are already pooled by utils.Sha256.
+var leavesPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([][32]byte, 0, 1024)
+		return &buf
+	},
+}
+
+// getLeavesBuffer returns a pooled copy of leaves with room for one padding leaf.
+func getLeavesBuffer(leaves [][32]byte) *[][32]byte {
+	buf := leavesPool.Get().(*[][32]byte)
+	if cap(*buf) < len(leaves)+1 {
+		*buf = make([][32]byte, 0, len(leaves)+1)
+	}
+	*buf = append((*buf)[:0], leaves...)
+	return buf
+}
+
+func putLeavesBuffer(buf *[][32]byte) {
+	leavesPool.Put(buf)
+}
+
 const initialBufferSize = 0 // it is whatever
 
 // merkleHasher is used internally to provide shared buffer internally to the merkle_tree package.
@@ -85,15 +107,12 @@ func (m *merkleHasher) transactionsListRoot(transactions [][]byte) ([32]byte, er
 			return [32]byte{}, err
 		}
 
-		lengthRoot := Uint64Root(transactionLength)
-		leaves[i] = utils.Sha256(transactionsBaseRoot[:], lengthRoot[:])
+		leaves[i] = MixInLength(transactionsBaseRoot, transactionLength)
 	}
 	transactionsBaseRoot, err := MerkleizeVector(leaves, 1048576)
 	if err != nil {
 		return libcommon.Hash{}, err
 	}
 
-	countRoot := Uint64Root(txCount)
-
-	return utils.Sha256(transactionsBaseRoot[:], countRoot[:]), nil
+	return MixInLength(transactionsBaseRoot, txCount), nil
 }
@@ -1,6 +1,8 @@
 package merkle_tree
 
 import (
+	"encoding/binary"
+	"fmt"
 	"math/bits"
 
 	"github.com/prysmaticlabs/gohashtree"
@@ -8,12 +10,12 @@ import (
 	"github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/common/length"
 	"github.com/ledgerwatch/erigon-lib/types/ssz"
-
-	"github.com/ledgerwatch/erigon/cl/utils"
 )
 
 // MerkleizeVector uses our optimized routine to hash a list of 32-byte
-// elements.
+// elements. The tree depth is floor(log2(length)), so length must be a power of two,
+// and the hashing is done in place: the content of elements is overwritten.
+// Use VectorRoot or ListRoot when either of these is a concern.
 func MerkleizeVector(elements [][32]byte, length uint64) ([32]byte, error) {
 	depth := GetDepth(length)
 	// Return zerohash at depth
@@ -70,8 +72,43 @@ func BitlistRootWithLimit(bits []byte, limit uint64) ([32]byte, error) {
 		return [32]byte{}, err
 	}
 
-	lengthRoot := Uint64Root(size)
-	return utils.Sha256(base[:], lengthRoot[:]), nil
+	return MixInLength(base, size), nil
+}
+
+// BitlistRoot computes the root of an SSZ Bitlist[limit] given its serialized form, where the
+// trailing sentinel bit gives the bit length. Unlike BitlistRootWithLimit, the encoding is
+// validated and limit does not need to be a power-of-two multiple of 256.
+func BitlistRoot(bitlist []byte, limit uint64) ([32]byte, error) {
+	if len(bitlist) == 0 || bitlist[len(bitlist)-1] == 0 {
+		return [32]byte{}, fmt.Errorf("bitlist is missing its length bit")
+	}
+	if size := uint64(8*(len(bitlist)-1) + bits.Len8(bitlist[len(bitlist)-1]) - 1); size > limit {
+		return [32]byte{}, fmt.Errorf("bitlist length %d exceeds limit %d", size, limit)
+	}
+	return BitlistRootWithLimit(bitlist, NextPowerOfTwo((limit+255)/256)*256)
+}
+
+// BitvectorRoot computes the root of an SSZ Bitvector[bitLength] given its serialized form: the bits
+// are packed into 32-byte chunks and merkleized without length mix-in, unlike a bitlist. The input
+// must be exactly (bitLength+7)/8 bytes long and the padding bits of the last byte must be unset.
+func BitvectorRoot(bits []byte, bitLength uint64) ([32]byte, error) {
+	if bitLength == 0 {
+		return [32]byte{}, fmt.Errorf("bitvector length must be positive")
+	}
+	if uint64(len(bits)) != (bitLength+7)/8 {
+		return [32]byte{}, fmt.Errorf("bitvector of %d bits must be %d bytes, got %d", bitLength, (bitLength+7)/8, len(bits))
+	}
+	if bitLength%8 != 0 && bits[len(bits)-1]>>(bitLength%8) != 0 {
+		return [32]byte{}, fmt.Errorf("bitvector has bits set past its length %d", bitLength)
+	}
+	chunks := getLeavesBuffer(nil)
+	defer putLeavesBuffer(chunks)
+	for i := 0; i < len(bits); i += length.Hash {
+		var chunk [32]byte
+		copy(chunk[:], bits[i:])
+		*chunks = append(*chunks, chunk)
+	}
+	return MerkleizeVector(*chunks, NextPowerOfTwo((bitLength+255)/256))
 }
 
 func packBits(bytes []byte) [][32]byte {
@@ -106,7 +143,12 @@ func TransactionsListRoot(transactions [][]byte) ([32]byte, error) {
 	return globalHasher.transactionsListRoot(transactions)
 }
 
+// ListObjectSSZRoot computes the root of an SSZ list of objects with the given maximum length, from the
+// roots of its elements. Any type with a HashSSZ method can be rooted this way, interfaces included.
 func ListObjectSSZRoot[T ssz.HashableSSZ](list []T, limit uint64) ([32]byte, error) {
+	if uint64(len(list)) > limit {
+		return [32]byte{}, fmt.Errorf("list length %d exceeds limit %d", len(list), limit)
+	}
 	globalHasher.mu2.Lock()
 	defer globalHasher.mu2.Unlock()
 	// due to go generics we cannot make a method for global hasher.
@@ -122,6 +164,54 @@ func ListObjectSSZRoot[T ssz.HashableSSZ](list []T, limit uint64) ([32]byte, err
 	if err != nil {
 		return [32]byte{}, err
 	}
-	lenLeaf := Uint64Root(uint64(len(list)))
-	return utils.Sha256(vectorLeaf[:], lenLeaf[:]), nil
+	return MixInLength(vectorLeaf, uint64(len(list))), nil
+}
+
+// ListRoot computes the root of an SSZ list of 32-byte leaves with the given maximum length:
+// the leaves are padded with zero hashes up to the next power of two of limit and the list
+// length is mixed in. The leaves slice is not modified.
+func ListRoot(leaves [][32]byte, limit uint64) ([32]byte, error) {
+	if uint64(len(leaves)) > limit {
+		return [32]byte{}, fmt.Errorf("list length %d exceeds limit %d", len(leaves), limit)
+	}
+	elements := getLeavesBuffer(leaves)
+	defer putLeavesBuffer(elements)
+	base, err := MerkleizeVector(*elements, NextPowerOfTwo(limit))
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return MixInLength(base, uint64(len(leaves))), nil
+}
+
+// VectorRoot computes the root of an SSZ vector of 32-byte leaves. length is the declared
+// vector length (not len(leaves)): missing trailing leaves are treated as zero and the tree
+// is padded with zero hashes up to the next power of two. The leaves slice is not modified.
+func VectorRoot(leaves [][32]byte, length uint64) ([32]byte, error) {
+	if uint64(len(leaves)) > length {
+		return [32]byte{}, fmt.Errorf("vector has %d leaves, more than its length %d", len(leaves), length)
+	}
+	elements := getLeavesBuffer(leaves)
+	defer putLeavesBuffer(elements)
+	return MerkleizeVector(*elements, NextPowerOfTwo(length))
+}
+
+// Uint64ListRoot computes the root of an SSZ List[uint64, limit]: the values are packed four per
+// 32-byte chunk, merkleized up to the chunk limit and the number of values is mixed in.
+func Uint64ListRoot(values []uint64, limit uint64) ([32]byte, error) {
+	if uint64(len(values)) > limit {
+		return [32]byte{}, fmt.Errorf("list length %d exceeds limit %d", len(values), limit)
+	}
+	chunks := getLeavesBuffer(nil)
+	defer putLeavesBuffer(chunks)
+	for i, v := range values {
+		if i%4 == 0 {
+			*chunks = append(*chunks, [32]byte{})
+		}
+		binary.LittleEndian.PutUint64((*chunks)[i/4][(i%4)*8:], v)
+	}
+	base, err := MerkleizeVector(*chunks, NextPowerOfTwo((limit*8+31)/32))
+	if err != nil {
+		return [32]byte{}, err
+	}
+	return MixInLength(base, uint64(len(values))), nil
 }
@@ -0,0 +1,265 @@
+package merkle_tree_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon/cl/merkle_tree"
+	"github.com/stretchr/testify/require"
+)
+
+func testLeaves(n int) [][32]byte {
+	leaves := make([][32]byte, n)
+	for i := range leaves {
+		leaves[i][0] = byte(i%255 + 1)
+	}
+	return leaves
+}
+
+func TestMixInLength(t *testing.T) {
+	var root [32]byte
+	for i := range root {
+		root[i] = byte(i)
+	}
+	tests := []struct {
+		length   uint64
+		expected common.Hash
+	}{
+		{length: 0, expected: common.HexToHash("0x5576ce645abbf23973c63a02b3cdb0efc8ed3c9bd7dac3845f6b9ad6820b4bde")},
+		{length: 1, expected: common.HexToHash("0xe987b42bd50123fe7764ebae4f4155beebd99b9ede2613a632484aa090e270df")},
+		{length: 1 << 32, expected: common.HexToHash("0x479f0052c8012c25a3e14baf5f66158291957196a170ee0bb10ed9dea7a5eae9")},
+		{length: math.MaxUint64, expected: common.HexToHash("0xa15779b50360aa69af0a575930aa2ab6a42bb5e28c93838dbb7ae339a92ab703")},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.expected, common.Hash(merkle_tree.MixInLength(root, tt.length)), "length %d", tt.length)
+	}
+	// An empty list of a single chunk is the zero hash one level up.
+	require.Equal(t, merkle_tree.ZeroHashes[1], merkle_tree.MixInLength(merkle_tree.ZeroHashes[0], 0))
+}
+
+func TestListRoot(t *testing.T) {
+	tests := []struct {
+		name     string
+		leaves   [][32]byte
+		limit    uint64
+		expected common.Hash
+	}{
+		{name: "empty", leaves: nil, limit: 16, expected: common.HexToHash("0x792930bbd5baac43bcc798ee49aa8185ef76bb3b44ba62b91d86ae569e4bb535")},
+		{name: "partial", leaves: testLeaves(3), limit: 16, expected: common.HexToHash("0x64f530f81fda34b28310ccd4c6f62a81ef953b2bce89e714705b1bbe51012b1b")},
+		{name: "full", leaves: testLeaves(16), limit: 16, expected: common.HexToHash("0x342b821c15669df58dd85ea7b8f04e3259bf46edf9f2f0d65d36d911e1c6d6b1")},
+		{name: "non power of two limit", leaves: testLeaves(3), limit: 5, expected: common.HexToHash("0xf03f83e4c3bb78459c212a370280b2355cc21c57ede0036a434138a14eed551a")},
+		{name: "512 leaves", leaves: testLeaves(512), limit: 512, expected: common.HexToHash("0xf8c6e65d9fa7e0f886794f54da9b4de6505ae2f2fa01b1b7a332ededc92ef5f9")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			leaves := append([][32]byte(nil), tt.leaves...)
+			root, err := merkle_tree.ListRoot(tt.leaves, tt.limit)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, common.Hash(root))
+			// The input leaves must be left untouched.
+			require.Equal(t, leaves, append([][32]byte(nil), tt.leaves...))
+		})
+	}
+
+	_, err := merkle_tree.ListRoot(testLeaves(17), 16)
+	require.Error(t, err)
+}
+
+func TestVectorRoot(t *testing.T) {
+	tests := []struct {
+		leaves   [][32]byte
+		length   uint64
+		expected common.Hash
+	}{
+		{leaves: testLeaves(1), length: 1, expected: common.HexToHash("0x0100000000000000000000000000000000000000000000000000000000000000")},
+		{leaves: testLeaves(2), length: 2, expected: common.HexToHash("0xff55c97976a840b4ced964ed49e3794594ba3f675238b5fd25d282b60f70a194")},
+		{leaves: testLeaves(3), length: 3, expected: common.HexToHash("0x66c419026fee8793be7fd0011b9db46b98a79f9c9b640e25317865c358f442db")},
+		{leaves: testLeaves(4), length: 4, expected: common.HexToHash("0xbfe3c665d2e561f13b30606c580cb703b2041287e212ade110f0bfd8563e21bb")},
+		{leaves: testLeaves(512), length: 512, expected: common.HexToHash("0x4583245996966fc9746f1d2db6a4cdd8cd8215d21aa0ceb76e01e15afb07a8d9")},
+		// Trailing zero leaves can be omitted.
+		{leaves: testLeaves(1), length: 3, expected: common.HexToHash("0x553c8ccfd20bb4db224b1ae47359e9968a5c8098c15d8bf728b19e55749c773b")},
+	}
+	for _, tt := range tests {
+		root, err := merkle_tree.VectorRoot(tt.leaves, tt.length)
+		require.NoError(t, err)
+		require.Equal(t, tt.expected, common.Hash(root), "length %d", tt.length)
+	}
+
+	_, err := merkle_tree.VectorRoot(testLeaves(5), 4)
+	require.Error(t, err)
+}
+
+// TestVectorRootHistoricalRoots roots block_roots/state_roots sized vectors, Vector[Root, SLOTS_PER_HISTORICAL_ROOT].
+func TestVectorRootHistoricalRoots(t *testing.T) {
+	const slotsPerHistoricalRoot = 8192
+	root := func(i int, tag byte) [32]byte {
+		return [32]byte{byte(i), byte(i >> 8), tag}
+	}
+
+	sparse := make([][32]byte, slotsPerHistoricalRoot)
+	for _, i := range []int{0, 1, 100, 4095, 8191} {
+		sparse[i] = root(i, 0xaa)
+	}
+	expected := common.HexToHash("0xf8de26f7a156c236efe786fd74b7109f832b1806233fcf07a2c7d546263c3adc")
+	sparseRoot, err := merkle_tree.VectorRoot(sparse, slotsPerHistoricalRoot)
+	require.NoError(t, err)
+	require.Equal(t, expected, common.Hash(sparseRoot))
+	// Trailing zero roots may be left out, they are padded from the zero hashes.
+	truncatedRoot, err := merkle_tree.VectorRoot(sparse[:8191], slotsPerHistoricalRoot)
+	require.NoError(t, err)
+	require.NotEqual(t, sparseRoot, truncatedRoot)
+	sparse[8191] = [32]byte{}
+	untilLastRoot, err := merkle_tree.VectorRoot(sparse, slotsPerHistoricalRoot)
+	require.NoError(t, err)
+	require.Equal(t, untilLastRoot, truncatedRoot)
+	truncatedRoot, err = merkle_tree.VectorRoot(sparse[:4096], slotsPerHistoricalRoot)
+	require.NoError(t, err)
+	require.Equal(t, untilLastRoot, truncatedRoot)
+
+	full := make([][32]byte, slotsPerHistoricalRoot)
+	for i := range full {
+		full[i] = root(i, 0xbb)
+	}
+	fullRoot, err := merkle_tree.VectorRoot(full, slotsPerHistoricalRoot)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("0x66ac5b067db1b4a33f1d8148867dd72cc9ba437d2e158eeb65480236df8f30a0"), common.Hash(fullRoot))
+	require.Equal(t, root(0, 0xbb), full[0], "the roots must not be hashed in place")
+
+	_, err = merkle_tree.VectorRoot(append(full, [32]byte{}), slotsPerHistoricalRoot)
+	require.Error(t, err)
+}
+
+func TestUint64ListRoot(t *testing.T) {
+	values := make([]uint64, 16)
+	for i := range values {
+		values[i] = uint64(i)*1000000007 + 1
+	}
+	tests := []struct {
+		name     string
+		values   []uint64
+		limit    uint64
+		expected common.Hash
+	}{
+		{name: "empty", values: nil, limit: 16, expected: common.HexToHash("0x28ba1834a3a7b657460ce79fa3a1d909ab8828fd557659d4d0554a9bdbc0ec30")},
+		{name: "one", values: values[:1], limit: 16, expected: common.HexToHash("0x76f9439b26367975bb97a1010ef4309789d1814af63402a273b9db692dc89f48")},
+		{name: "one chunk", values: values[:4], limit: 16, expected: common.HexToHash("0xfb59f538d714c500745b8127318d3ea0eccdbb6401809a470422c9a68ea7d98e")},
+		{name: "partial chunk", values: values[:5], limit: 16, expected: common.HexToHash("0xefc61ec17c6155279630ecc65ce56f2c55916b70f90ce24e64472850acff1c3e")},
+		{name: "full", values: values, limit: 16, expected: common.HexToHash("0x7ecf1fe5e7c220d24c3053350506101d0f74ddee87791a9730cfafac22d50780")},
+		{name: "registry limit", values: values[:5], limit: 1 << 40, expected: common.HexToHash("0x3e85f0a5df56ddb8b5d4a036c3a1c10d2d0f4d0bc6246920568017cd9dba4035")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := merkle_tree.Uint64ListRoot(tt.values, tt.limit)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, common.Hash(root))
+		})
+	}
+
+	_, err := merkle_tree.Uint64ListRoot(values, 15)
+	require.Error(t, err)
+}
+
+// testBitlist returns the serialization of a bitlist of n set bits.
+func testBitlist(n int) []byte {
+	bits := make([]byte, n/8+1)
+	for i := 0; i < n; i++ {
+		bits[i/8] |= 1 << (i % 8)
+	}
+	bits[n/8] |= 1 << (n % 8)
+	return bits
+}
+
+func TestBitlistRoot(t *testing.T) {
+	tests := []struct {
+		name     string
+		bits     []byte
+		limit    uint64
+		expected common.Hash
+	}{
+		{name: "empty", bits: testBitlist(0), limit: 2048, expected: common.HexToHash("0xe8e527e84f666163a90ef900e013f56b0a4d020148b2224057b719f351b003a6")},
+		{name: "one", bits: testBitlist(1), limit: 2048, expected: common.HexToHash("0x9e1ff035a32c3d3085074e676356984c077f70bed47814956a9ef8852dcb8161")},
+		{name: "255", bits: testBitlist(255), limit: 2048, expected: common.HexToHash("0xd03f41938b89381d86cf714c7e1da455ad1ad81fa536efceb0ec0e3f593ac3aa")},
+		{name: "256", bits: testBitlist(256), limit: 2048, expected: common.HexToHash("0x9eb31f16a445d6fa40aa3c3aa47f7d8b960299c1a5f953e9df0af00371fc1c85")},
+		{name: "257", bits: testBitlist(257), limit: 2048, expected: common.HexToHash("0xd2122456b7a2ed0bee7cd2de8bdce3e4d3619346f9b9e33dc21360c8c8e50790")},
+		{name: "512", bits: testBitlist(512), limit: 2048, expected: common.HexToHash("0x8d1526bd2d12505f4ab52daedc86b480b1ab1b02ac3c08053427d095975fd351")},
+		{name: "non power of two chunk limit", bits: testBitlist(257), limit: 600, expected: common.HexToHash("0x636a0cab160dc28fb3f0ac5b454c30e2726eb8969828f1dcea1a444d75a36f05")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := merkle_tree.BitlistRoot(tt.bits, tt.limit)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, common.Hash(root))
+		})
+	}
+
+	_, err := merkle_tree.BitlistRoot(nil, 2048)
+	require.Error(t, err)
+	_, err = merkle_tree.BitlistRoot([]byte{0xff, 0x00}, 2048)
+	require.Error(t, err)
+	_, err = merkle_tree.BitlistRoot(testBitlist(257), 256)
+	require.Error(t, err)
+}
+
+func TestBitvectorRoot(t *testing.T) {
+	partial := make([]byte, 64)
+	partial[0], partial[1], partial[63] = 0xff, 0x0f, 0x80
+	allSet := make([]byte, 64)
+	for i := range allSet {
+		allSet[i] = 0xff
+	}
+	threeChunks := make([]byte, 96)
+	for i := range threeChunks {
+		threeChunks[i] = 0xff
+	}
+	tests := []struct {
+		name      string
+		bits      []byte
+		bitLength uint64
+		expected  common.Hash
+	}{
+		{name: "512 unset", bits: make([]byte, 64), bitLength: 512, expected: common.HexToHash("0xf5a5fd42d16a20302798ef6ed309979b43003d2320d9f0e8ea9831a92759fb4b")},
+		{name: "512 set", bits: allSet, bitLength: 512, expected: common.HexToHash("0x8667e718294e9e0df1d30600ba3eeb201f764aad2dad72748643e4a285e1d1f7")},
+		{name: "512 partial", bits: partial, bitLength: 512, expected: common.HexToHash("0x8aee8339f2faac38042c2d5e890fd23d03332d54c44e4313dc832b98cfc5ba92")},
+		{name: "4 bits", bits: []byte{0x05}, bitLength: 4, expected: common.HexToHash("0x0500000000000000000000000000000000000000000000000000000000000000")},
+		{name: "768 set", bits: threeChunks, bitLength: 768, expected: common.HexToHash("0x4a6ba660d16b4dde152d00ba82cdde34827411f341c56b102e7962410924ad36")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root, err := merkle_tree.BitvectorRoot(tt.bits, tt.bitLength)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, common.Hash(root))
+		})
+	}
+
+	_, err := merkle_tree.BitvectorRoot(make([]byte, 63), 512)
+	require.Error(t, err)
+	_, err = merkle_tree.BitvectorRoot(make([]byte, 65), 512)
+	require.Error(t, err)
+	_, err = merkle_tree.BitvectorRoot([]byte{0x10}, 4)
+	require.Error(t, err)
+	_, err = merkle_tree.BitvectorRoot(nil, 0)
+	require.Error(t, err)
+}
+
+func BenchmarkListRoot(b *testing.B) {
+	leaves := testLeaves(512)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := merkle_tree.ListRoot(leaves, 512); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkVectorRootHistoricalRoots(b *testing.B) {
+	roots := testLeaves(8192)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := merkle_tree.VectorRoot(roots, 8192); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
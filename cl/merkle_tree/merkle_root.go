@@ -158,3 +158,20 @@ func MerkleProof(depth, proofIndex int, schema ...interface{}) ([][32]byte, erro
 	}
 	return proof, nil
 }
+
+// VerifyProof checks that leaf is at the given leaf index of the tree with the given root, where proof is the
+// sibling path ordered from the bottom up, as returned by MerkleProof.
+func VerifyProof(root, leaf [32]byte, proof [][32]byte, index int) bool {
+	if index < 0 || (len(proof) < 63 && index >= 1<<len(proof)) {
+		return false
+	}
+	value := leaf
+	for i := range proof {
+		if (index>>i)&1 == 1 {
+			value = utils.Sha256(proof[i][:], value[:])
+		} else {
+			value = utils.Sha256(value[:], proof[i][:])
+		}
+	}
+	return value == root
+}
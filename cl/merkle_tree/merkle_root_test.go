@@ -1,6 +1,7 @@
 package merkle_tree_test
 
 import (
+	"crypto/sha256"
 	_ "embed"
 	"testing"
 
@@ -33,3 +34,84 @@ func TestHashTreeRootTxs(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, common.Hash(root), common.HexToHash("0x987269bc1075122edff32bfc38479757103cee5c1ed6e990de7ffee85b5dd18a"))
 }
+
+func TestSigningRoot(t *testing.T) {
+	objectRoot := common.HexToHash("0x0101010101010101010101010101010101010101010101010101010101010101")
+	domain := common.HexToHash("0x0400000000000000000000000000000000000000000000000000000000000000")
+	expected, err := merkle_tree.HashTreeRoot(objectRoot[:], domain[:])
+	require.NoError(t, err)
+	root := merkle_tree.SigningRoot(objectRoot, domain)
+	require.Equal(t, expected, root)
+	require.Equal(t, common.HexToHash("0x565da5d3215335ce43510eaa50f867d4e1b36ffba8c45c7dc0d71db472ca4368"), common.Hash(root))
+}
+
+func TestPublicKeyRoot(t *testing.T) {
+	var pubkey common.Bytes48
+	for i := range pubkey {
+		pubkey[i] = byte(i)
+	}
+	root := merkle_tree.PublicKeyRoot(pubkey)
+	require.Equal(t, common.HexToHash("0xb976c9abe97b4f03d7e4058246713687379d2718a829ab66e2a93aa924e43c1d"), common.Hash(root))
+	expected, err := merkle_tree.HashTreeRoot(pubkey[:])
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+	// Two chunks, the second one zero padded.
+	var second [32]byte
+	copy(second[:], pubkey[32:])
+	require.Equal(t, utils.Sha256(pubkey[:32], second[:]), root)
+
+	require.Equal(t, merkle_tree.ZeroHashes[1], merkle_tree.PublicKeyRoot(common.Bytes48{}))
+}
+
+func TestSignatureRoot(t *testing.T) {
+	var signature common.Bytes96
+	for i := range signature {
+		signature[i] = byte(i)
+	}
+	root, err := merkle_tree.SignatureRoot(signature)
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("0x17c8d5caa3d7162e8dada90de6e741783f3d73736498c11eba34974fbf5464f3"), common.Hash(root))
+	// Same as the signature field of any container.
+	expected, err := merkle_tree.HashTreeRoot(signature[:])
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+	// Three chunks padded to four.
+	var chunks [4][32]byte
+	copy(chunks[0][:], signature[0:32])
+	copy(chunks[1][:], signature[32:64])
+	copy(chunks[2][:], signature[64:96])
+	left := utils.Sha256(chunks[0][:], chunks[1][:])
+	right := utils.Sha256(chunks[2][:], chunks[3][:])
+	require.Equal(t, utils.Sha256(left[:], right[:]), root)
+
+	root, err = merkle_tree.SignatureRoot(common.Bytes96{})
+	require.NoError(t, err)
+	require.Equal(t, merkle_tree.ZeroHashes[2], root)
+
+	// The point at infinity.
+	root, err = merkle_tree.SignatureRoot(common.Bytes96{0xc0})
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("0x8e742f5552fe005d58a4676d4c9709ac5cba3fc36cb99e387dcb2414edf53252"), common.Hash(root))
+}
+
+// TestMerkleizationIsSha256 guards against merkleizing with anything other than SHA-256, as the consensus spec requires.
+func TestMerkleizationIsSha256(t *testing.T) {
+	// Mainnet genesis fork digest: the first 4 bytes of hash_tree_root(ForkData(0x00000000, genesis_validators_root)).
+	genesisValidatorsRoot := common.HexToHash("0x4b363db94e286120d76eb905340fdd4e54bfe9f06bf33ff6cf5ad27f511bfe95")
+	forkDataRoot, err := merkle_tree.HashTreeRoot(make([]byte, 32), genesisValidatorsRoot[:])
+	require.NoError(t, err)
+	require.Equal(t, []byte{0xb5, 0x30, 0x3f, 0x2a}, forkDataRoot[:4])
+
+	leaves := [][32]byte{{1}, {2}, {3}, {4}}
+	left := sha256.Sum256(append(leaves[0][:], leaves[1][:]...))
+	right := sha256.Sum256(append(leaves[2][:], leaves[3][:]...))
+	expected := sha256.Sum256(append(left[:], right[:]...))
+
+	out := make([]byte, 32)
+	require.NoError(t, merkle_tree.HashByteSlice(out, append(leaves[0][:], leaves[1][:]...)))
+	require.Equal(t, left[:], out)
+
+	root, err := merkle_tree.MerkleizeVector(leaves, 4)
+	require.NoError(t, err)
+	require.Equal(t, expected, root)
+}
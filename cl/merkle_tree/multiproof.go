@@ -0,0 +1,120 @@
+package merkle_tree
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ledgerwatch/erigon/cl/utils"
+)
+
+// MultiProof returns the proof covering all the given generalized indices at once, over the tree whose
+// bottom layer is leaves padded with zero leaves to the next power of two. The root has generalized
+// index 1 and the i-th leaf has generalized index NextPowerOfTwo(len(leaves)) + i; indices may also
+// point to inner nodes. As in the consensus spec, the proof is made of the helper nodes needed to
+// recompute the root, sorted by decreasing generalized index.
+func MultiProof(leaves [][32]byte, indices []uint64) ([][32]byte, error) {
+	if len(indices) == 0 {
+		return nil, fmt.Errorf("no indices to prove")
+	}
+	width := NextPowerOfTwo(uint64(len(leaves)))
+	seen := make(map[uint64]struct{}, len(indices))
+	for _, index := range indices {
+		if index == 0 || index >= 2*width {
+			return nil, fmt.Errorf("generalized index %d out of range for a tree of %d leaves", index, width)
+		}
+		if _, ok := seen[index]; ok {
+			return nil, fmt.Errorf("duplicate generalized index %d", index)
+		}
+		seen[index] = struct{}{}
+	}
+
+	nodes := make([][32]byte, 2*width)
+	copy(nodes[width:], leaves)
+	for i := width - 1; i > 0; i-- {
+		nodes[i] = utils.Sha256(nodes[2*i][:], nodes[2*i+1][:])
+	}
+
+	helpers := multiProofHelperIndices(indices)
+	proof := make([][32]byte, len(helpers))
+	for i, index := range helpers {
+		proof[i] = nodes[index]
+	}
+	return proof, nil
+}
+
+// VerifyMultiProof checks that the given nodes sit at the given generalized indices of the tree with the
+// given root, using a proof as returned by MultiProof.
+func VerifyMultiProof(root [32]byte, nodes [][32]byte, proof [][32]byte, indices []uint64) bool {
+	if len(nodes) != len(indices) || len(indices) == 0 {
+		return false
+	}
+	objects := make(map[uint64][32]byte, len(indices)+len(proof))
+	for i, index := range indices {
+		if index == 0 {
+			return false
+		}
+		if _, ok := objects[index]; ok {
+			return false
+		}
+		objects[index] = nodes[i]
+	}
+	helpers := multiProofHelperIndices(indices)
+	if len(proof) != len(helpers) {
+		return false
+	}
+	for i, index := range helpers {
+		objects[index] = proof[i]
+	}
+
+	keys := make([]uint64, 0, len(objects))
+	for index := range objects {
+		keys = append(keys, index)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] > keys[j] })
+	// Keys are processed from the deepest up, parents are queued as soon as both children are known.
+	// A parent that is already known, because both an index and one of its ancestors are proven, must
+	// match its children.
+	for pos := 0; pos < len(keys); pos++ {
+		index := keys[pos]
+		if index == 1 {
+			continue
+		}
+		left, okLeft := objects[index&^1]
+		right, okRight := objects[index|1]
+		if !okLeft || !okRight {
+			continue
+		}
+		parent := utils.Sha256(left[:], right[:])
+		if known, ok := objects[index/2]; ok {
+			if known != parent {
+				return false
+			}
+			continue
+		}
+		objects[index/2] = parent
+		keys = append(keys, index/2)
+	}
+	computed, ok := objects[1]
+	return ok && computed == root
+}
+
+// multiProofHelperIndices returns the generalized indices of the nodes needed to prove indices: the
+// siblings along their paths to the root, except for those already on one of the paths.
+func multiProofHelperIndices(indices []uint64) []uint64 {
+	branch := make(map[uint64]struct{})
+	path := make(map[uint64]struct{})
+	for _, index := range indices {
+		for _, sibling := range GetPathIndices(index) {
+			branch[sibling] = struct{}{}
+			path[sibling^1] = struct{}{}
+		}
+	}
+	helpers := make([]uint64, 0, len(branch))
+	for index := range branch {
+		if _, ok := path[index]; !ok {
+			helpers = append(helpers, index)
+		}
+	}
+	sort.Slice(helpers, func(i, j int) bool { return helpers[i] > helpers[j] })
+	return helpers
+}
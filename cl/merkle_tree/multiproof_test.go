@@ -0,0 +1,81 @@
+package merkle_tree_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cl/merkle_tree"
+	"github.com/ledgerwatch/erigon/cl/utils"
+)
+
+func TestMultiProof(t *testing.T) {
+	leaves := testLeaves(13)
+	root, err := merkle_tree.VectorRoot(leaves, uint64(len(leaves)))
+	require.NoError(t, err)
+	// 13 leaves are padded to 16, so leaves have generalized indices 16 to 31.
+	tree := make([][32]byte, 32)
+	copy(tree[16:], leaves)
+	for i := 15; i > 0; i-- {
+		tree[i] = utils.Sha256(tree[2*i][:], tree[2*i+1][:])
+	}
+	require.Equal(t, root, tree[1])
+
+	tests := []struct {
+		name      string
+		indices   []uint64
+		proofSize int
+	}{
+		{name: "single leaf", indices: []uint64{21}, proofSize: 4},
+		{name: "siblings", indices: []uint64{20, 21}, proofSize: 3},
+		{name: "far apart", indices: []uint64{16, 31}, proofSize: 6},
+		{name: "padding leaf", indices: []uint64{30}, proofSize: 4},
+		{name: "inner nodes", indices: []uint64{2, 12}, proofSize: 2},
+		{name: "leaf and its ancestor", indices: []uint64{17, 4}, proofSize: 4},
+		{name: "many leaves", indices: []uint64{16, 18, 19, 23, 24, 28}, proofSize: 7},
+		{name: "all leaves", indices: []uint64{16, 17, 18, 19, 20, 21, 22, 23, 24, 25, 26, 27, 28, 29, 30, 31}, proofSize: 0},
+		{name: "root", indices: []uint64{1}, proofSize: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proof, err := merkle_tree.MultiProof(leaves, tt.indices)
+			require.NoError(t, err)
+			require.Len(t, proof, tt.proofSize)
+			nodes := make([][32]byte, len(tt.indices))
+			for i, index := range tt.indices {
+				nodes[i] = tree[index]
+			}
+			require.True(t, merkle_tree.VerifyMultiProof(root, nodes, proof, tt.indices))
+
+			nodes[0][31] ^= 1
+			require.False(t, merkle_tree.VerifyMultiProof(root, nodes, proof, tt.indices))
+			nodes[0][31] ^= 1
+			if len(proof) > 0 {
+				proof[len(proof)-1][31] ^= 1
+				require.False(t, merkle_tree.VerifyMultiProof(root, nodes, proof, tt.indices))
+				require.False(t, merkle_tree.VerifyMultiProof(root, nodes, proof[:len(proof)-1], tt.indices))
+			}
+		})
+	}
+
+	_, err = merkle_tree.MultiProof(leaves, nil)
+	require.Error(t, err)
+	_, err = merkle_tree.MultiProof(leaves, []uint64{0})
+	require.Error(t, err)
+	_, err = merkle_tree.MultiProof(leaves, []uint64{32})
+	require.Error(t, err)
+	_, err = merkle_tree.MultiProof(leaves, []uint64{20, 20})
+	require.Error(t, err)
+}
+
+func TestMultiProofSingleLeafMatchesBranch(t *testing.T) {
+	leaves := testLeaves(8)
+	root, err := merkle_tree.VectorRoot(leaves, 8)
+	require.NoError(t, err)
+	for i := range leaves {
+		proof, err := merkle_tree.MultiProof(leaves, []uint64{8 + uint64(i)})
+		require.NoError(t, err)
+		// A single leaf multiproof is its bottom-up branch.
+		require.True(t, merkle_tree.VerifyProof(root, leaves[i], proof, i))
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/common/length"
+	"github.com/ledgerwatch/erigon/cl/utils"
 )
 
 // Uint64Root retrieves the root hash of a uint64 value by converting it to a byte array and returning it as a hash.
@@ -14,6 +15,13 @@ func Uint64Root(val uint64) libcommon.Hash {
 	return root
 }
 
+// MixInLength returns the root of a list from the root of its elements and its length, as in the SSZ
+// mix_in_length: the length is encoded as a little endian 32 bytes chunk and hashed with the root.
+func MixInLength(root [32]byte, length uint64) [32]byte {
+	lengthRoot := Uint64Root(length)
+	return utils.Sha256(root[:], lengthRoot[:])
+}
+
 func BoolRoot(b bool) (root libcommon.Hash) {
 	if b {
 		root[0] = 1
@@ -39,3 +47,25 @@ func InPlaceRoot(key []byte) error {
 	}
 	return nil
 }
+
+// SignatureRoot computes the root of a BLS signature, a Vector[byte, 96]: the 96 bytes are split in three
+// 32 bytes chunks, padded with a zero chunk to the next power of two and merkleized, i.e.
+// hash(hash(c0, c1), hash(c2, zero)). The zero signature is not rejected: it is the valid placeholder
+// of unsigned objects such as the genesis block, and its root is ZeroHashes[2].
+func SignatureRoot(signature libcommon.Bytes96) ([32]byte, error) {
+	return BytesRoot(signature[:])
+}
+
+// PublicKeyRoot computes the root of a BLS public key, a Vector[byte, 48]: the first 32 bytes are the
+// first chunk, the last 16 bytes are zero padded to 32 to form the second one, and the root is
+// hash(c0, c1). Two chunks are already a power of two so no padding chunk is added.
+func PublicKeyRoot(pubkey libcommon.Bytes48) [32]byte {
+	var chunks [2 * length.Hash]byte
+	copy(chunks[:], pubkey[:])
+	return utils.Sha256(chunks[:length.Hash], chunks[length.Hash:])
+}
+
+// SigningRoot computes the root of the SigningData container (object_root, domain) that signatures are made over.
+func SigningRoot(objectRoot [32]byte, domain [32]byte) [32]byte {
+	return utils.Sha256(objectRoot[:], domain[:])
+}
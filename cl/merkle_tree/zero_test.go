@@ -0,0 +1,34 @@
+package merkle_tree_test
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon/cl/merkle_tree"
+	"github.com/ledgerwatch/erigon/cl/utils"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZeroHashes(t *testing.T) {
+	var expected [32]byte
+	for depth := range merkle_tree.ZeroHashes {
+		require.Equal(t, expected, merkle_tree.ZeroHashes[depth], "depth %d", depth)
+		expected = utils.Sha256(expected[:], expected[:])
+	}
+}
+
+func BenchmarkSparseVectorRoot(b *testing.B) {
+	const length = 1 << 16
+	leaves := testLeaves(1)
+	b.Run("zero hashes", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			merkle_tree.VectorRoot(leaves, length)
+		}
+	})
+	b.Run("materialized", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			padded := make([][32]byte, length)
+			copy(padded, leaves)
+			merkle_tree.VectorRoot(padded, length)
+		}
+	})
+}
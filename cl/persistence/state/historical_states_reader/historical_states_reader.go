@@ -151,12 +151,12 @@ func (r *HistoricalStatesReader) ReadHistoricalState(ctx context.Context, tx kv.
 	}
 	ret.SetValidators(validatorSet)
 	// Randomness
-	randaoMixes := solid.NewHashVector(int(r.cfg.EpochsPerHistoricalVector))
+	randaoMixes := solid.NewRandaoMixes(int(r.cfg.EpochsPerHistoricalVector))
 	if err := r.readRandaoMixes(tx, slot, randaoMixes); err != nil {
 		return nil, fmt.Errorf("failed to read randao mixes: %w", err)
 	}
 	ret.SetRandaoMixes(randaoMixes)
-	slashingsVector := solid.NewUint64VectorSSZ(int(r.cfg.EpochsPerSlashingsVector))
+	slashingsVector := solid.NewSlashings(int(r.cfg.EpochsPerSlashingsVector))
 	// Slashings
 	err = r.ReconstructUint64ListDump(tx, slot, kv.ValidatorSlashings, int(r.cfg.EpochsPerSlashingsVector), slashingsVector)
 	if err != nil {
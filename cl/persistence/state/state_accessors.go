@@ -205,7 +205,9 @@ func ReadNextSyncCommittee(tx kv.Tx, slot uint64) (committee *solid.SyncCommitte
 		return nil, nil
 	}
 	committee = &solid.SyncCommittee{}
-	copy(committee[:], v)
+	if err := committee.DecodeSSZ(v, 0); err != nil {
+		return nil, err
+	}
 	return
 }
 
@@ -219,7 +221,9 @@ func ReadCurrentSyncCommittee(tx kv.Tx, slot uint64) (committee *solid.SyncCommi
 		return nil, nil
 	}
 	committee = &solid.SyncCommittee{}
-	copy(committee[:], v)
+	if err := committee.DecodeSSZ(v, 0); err != nil {
+		return nil, err
+	}
 	return
 }
 
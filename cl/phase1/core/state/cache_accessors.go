@@ -13,7 +13,6 @@ import (
 	"github.com/Giulio2002/bls"
 	libcommon "github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon/cl/clparams"
-	"github.com/ledgerwatch/erigon/cl/cltypes"
 	"github.com/ledgerwatch/erigon/cl/utils"
 )
 
@@ -235,9 +234,10 @@ func (b *CachingBeaconState) ComputeNextSyncCommittee() (*solid.SyncCommittee, e
 	mix := b.GetRandaoMix(int(mixPosition))
 	seed := shuffling.GetSeed(b.BeaconConfig(), mix, epoch, beaconConfig.DomainSyncCommittee)
 	i := uint64(0)
-	syncCommitteePubKeys := make([]libcommon.Bytes48, 0, cltypes.SyncCommitteeSize)
+	syncCommitteeSize := int(beaconConfig.SyncCommitteeSize)
+	syncCommitteePubKeys := make([]libcommon.Bytes48, 0, syncCommitteeSize)
 	preInputs := shuffling.ComputeShuffledIndexPreInputs(b.BeaconConfig(), seed)
-	for len(syncCommitteePubKeys) < cltypes.SyncCommitteeSize {
+	for len(syncCommitteePubKeys) < syncCommitteeSize {
 		shuffledIndex, err := shuffling.ComputeShuffledIndex(
 			b.BeaconConfig(),
 			i%activeValidatorCount,
@@ -266,7 +266,7 @@ func (b *CachingBeaconState) ComputeNextSyncCommittee() (*solid.SyncCommittee, e
 		i++
 	}
 	// Format public keys.
-	formattedKeys := make([][]byte, cltypes.SyncCommitteeSize)
+	formattedKeys := make([][]byte, syncCommitteeSize)
 	for i := range formattedKeys {
 		formattedKeys[i] = make([]byte, 48)
 		copy(formattedKeys[i], syncCommitteePubKeys[i][:])
@@ -0,0 +1,36 @@
+package raw
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/stretchr/testify/require"
+)
+
+// minimalPhase0Config returns the mainnet config with the phase0 minimal preset sizes.
+func minimalPhase0Config() *clparams.BeaconChainConfig {
+	cfg := clparams.MainnetBeaconConfig
+	cfg.SlotsPerEpoch = 8
+	cfg.SlotsPerHistoricalRoot = 64
+	cfg.EpochsPerHistoricalVector = 64
+	cfg.EpochsPerSlashingsVector = 64
+	cfg.EpochsPerEth1VotingPeriod = 4
+	return &cfg
+}
+
+func TestBeaconStatePhase0MinimalRoot(t *testing.T) {
+	state := New(minimalPhase0Config())
+	state.SetVersion(clparams.Phase0Version)
+	state.SetGenesisTime(1606824023)
+	state.SetSlot(5)
+	state.SetBlockRootAt(1, common.Hash{0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11,
+		0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11, 0x11})
+	state.SetRandaoMixAt(63, common.Hash{0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22,
+		0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22, 0x22})
+	state.SetSlashingSegmentAt(3, 7)
+
+	root, err := state.HashSSZ()
+	require.NoError(t, err)
+	require.Equal(t, common.HexToHash("0xe80085d852e801a8588a4436c071497425010dbd66ae4168465523546e23b542"), common.Hash(root))
+}
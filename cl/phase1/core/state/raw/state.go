@@ -14,7 +14,6 @@ const (
 	BlockRootsLength = 8192
 	StateRootsLength = 8192
 	RandoMixesLength = 65536
-	SlashingsLength  = 8192
 )
 
 type BeaconState struct {
@@ -72,21 +71,21 @@ func New(cfg *clparams.BeaconChainConfig) *BeaconState {
 		eth1Data:                     &cltypes.Eth1Data{},
 		eth1DataVotes:                solid.NewStaticListSSZ[*cltypes.Eth1Data](int(cfg.EpochsPerEth1VotingPeriod)*int(cfg.SlotsPerEpoch), 72),
 		historicalSummaries:          solid.NewStaticListSSZ[*cltypes.HistoricalSummary](int(cfg.HistoricalRootsLimit), 64),
-		currentSyncCommittee:         &solid.SyncCommittee{},
-		nextSyncCommittee:            &solid.SyncCommittee{},
+		currentSyncCommittee:         solid.NewSyncCommittee(int(cfg.SyncCommitteeSize)),
+		nextSyncCommittee:            solid.NewSyncCommittee(int(cfg.SyncCommitteeSize)),
 		latestExecutionPayloadHeader: &cltypes.Eth1Header{},
 		//inactivityScores: solid.NewSimpleUint64Slice(int(cfg.ValidatorRegistryLimit)),
 		inactivityScores:            solid.NewUint64ListSSZ(int(cfg.ValidatorRegistryLimit)),
 		balances:                    solid.NewUint64ListSSZ(int(cfg.ValidatorRegistryLimit)),
 		previousEpochParticipation:  solid.NewBitList(0, int(cfg.ValidatorRegistryLimit)),
 		currentEpochParticipation:   solid.NewBitList(0, int(cfg.ValidatorRegistryLimit)),
-		slashings:                   solid.NewUint64VectorSSZ(SlashingsLength),
+		slashings:                   solid.NewSlashings(int(cfg.EpochsPerSlashingsVector)),
 		currentEpochAttestations:    solid.NewDynamicListSSZ[*solid.PendingAttestation](int(cfg.CurrentEpochAttestationsLength())),
 		previousEpochAttestations:   solid.NewDynamicListSSZ[*solid.PendingAttestation](int(cfg.PreviousEpochAttestationsLength())),
 		historicalRoots:             solid.NewHashList(int(cfg.HistoricalRootsLimit)),
 		blockRoots:                  solid.NewHashVector(int(cfg.SlotsPerHistoricalRoot)),
 		stateRoots:                  solid.NewHashVector(int(cfg.SlotsPerHistoricalRoot)),
-		randaoMixes:                 solid.NewHashVector(int(cfg.EpochsPerHistoricalVector)),
+		randaoMixes:                 solid.NewRandaoMixes(int(cfg.EpochsPerHistoricalVector)),
 		validators:                  solid.NewValidatorSet(int(cfg.ValidatorRegistryLimit)),
 		previousJustifiedCheckpoint: solid.NewCheckpoint(),
 		currentJustifiedCheckpoint:  solid.NewCheckpoint(),
@@ -166,8 +166,8 @@ func TestForkChoiceChainBellatrix(t *testing.T) {
 	currentIntermediarySyncCommittee, nextIntermediarySyncCommittee, ok := store.GetSyncCommittees(cfg.SyncCommitteePeriod(store.HighestSeen()))
 	require.True(t, ok)
 
-	require.Equal(t, intermediaryState.CurrentSyncCommittee(), currentIntermediarySyncCommittee)
-	require.Equal(t, intermediaryState.NextSyncCommittee(), nextIntermediarySyncCommittee)
+	require.True(t, intermediaryState.CurrentSyncCommittee().Equal(currentIntermediarySyncCommittee))
+	require.True(t, intermediaryState.NextSyncCommittee().Equal(nextIntermediarySyncCommittee))
 
 	bs, has := store.GetLightClientBootstrap(intermediaryBlockRoot)
 	require.True(t, has)
@@ -22,6 +22,7 @@ import (
 	"github.com/ledgerwatch/erigon-lib/types/ssz"
 	"github.com/ledgerwatch/erigon/cl/clparams"
 	"github.com/ledgerwatch/erigon/cl/cltypes"
+	ssz2 "github.com/ledgerwatch/erigon/cl/ssz"
 	"github.com/ledgerwatch/erigon/cl/utils"
 	"github.com/ledgerwatch/log/v3"
 )
@@ -62,7 +63,11 @@ func NewGossipReceiver(
 func operationsContract[T ssz.EncodableSSZ](ctx context.Context, g *GossipManager, l log.Ctx, data *sentinel.GossipData, version int, name string, fn func(T, bool) error) error {
 	var t T
 	object := t.Clone().(T)
-	if err := object.DecodeSSZ(common.CopyBytes(data.Data), version); err != nil {
+	decode := object.DecodeSSZ
+	if strictObject, ok := any(object).(ssz2.StrictUnmarshaler); ok {
+		decode = strictObject.DecodeSSZStrict
+	}
+	if err := decode(common.CopyBytes(data.Data), version); err != nil {
 		g.sentinel.BanPeer(ctx, data.Peer)
 		l["at"] = fmt.Sprintf("decoding %s", name)
 		return err
@@ -240,7 +245,7 @@ func (g *GossipManager) onRecv(ctx context.Context, data *sentinel.GossipData, l
 			}
 		case gossip.IsTopicBeaconAttestation(data.Name):
 			att := &solid.Attestation{}
-			if err := att.DecodeSSZ(common.CopyBytes(data.Data), int(version)); err != nil {
+			if err := att.DecodeSSZStrict(common.CopyBytes(data.Data), int(version)); err != nil {
 				g.sentinel.BanPeer(ctx, data.Peer)
 				l["at"] = "decoding attestation"
 				return err
@@ -35,6 +35,8 @@ var writerPool = sync.Pool{
 	},
 }
 
+// EncodeAndWrite writes val as a req/resp chunk: the optional prefix, the uvarint length of the SSZ encoding and
+// the SSZ encoding compressed with the snappy frame format. Gossip uses the snappy block format, see utils.EncodeSSZSnappy.
 func EncodeAndWrite(w io.Writer, val ssz.Marshaler, prefix ...byte) error {
 	enc := make([]byte, 0, val.EncodingSizeSSZ())
 	var err error
@@ -78,6 +80,7 @@ func DecodeAndRead(r io.Reader, val ssz.EncodableSSZ, b *clparams.BeaconChainCon
 	return DecodeAndReadNoForkDigest(r, val, version)
 }
 
+// DecodeAndReadNoForkDigest reads a req/resp chunk written by EncodeAndWrite without a prefix into val.
 func DecodeAndReadNoForkDigest(r io.Reader, val ssz.EncodableSSZ, version clparams.StateVersion) error {
 	// Read varint for length of message.
 	encodedLn, _, err := ReadUvarint(r)
@@ -101,6 +104,37 @@ func DecodeAndReadNoForkDigest(r io.Reader, val ssz.EncodableSSZ, version clpara
 	return nil
 }
 
+// WriteLengthPrefixed writes val as the uvarint length of its SSZ encoding followed by the uncompressed encoding.
+func WriteLengthPrefixed(w io.Writer, val ssz.Marshaler) error {
+	enc, err := val.EncodeSSZ(make([]byte, 0, val.EncodingSizeSSZ()))
+	if err != nil {
+		return err
+	}
+	frame := binary.AppendUvarint(make([]byte, 0, binary.MaxVarintLen64+len(enc)), uint64(len(enc)))
+	_, err = w.Write(append(frame, enc...))
+	return err
+}
+
+// ReadLengthPrefixed reads a chunk written by WriteLengthPrefixed into val. The declared length is checked
+// against maxLen before the body is allocated.
+func ReadLengthPrefixed(r io.Reader, val ssz.EncodableSSZ, maxLen uint64, version clparams.StateVersion) error {
+	encodedLn, _, err := ReadUvarint(r)
+	if err != nil {
+		return fmt.Errorf("unable to read varint from message prefix: %w", err)
+	}
+	if encodedLn > maxLen {
+		return fmt.Errorf("payload too big: declared length %d, limit %d", encodedLn, maxLen)
+	}
+	raw := make([]byte, encodedLn)
+	if _, err := io.ReadFull(r, raw); err != nil {
+		return fmt.Errorf("unable to readPacket: %w", err)
+	}
+	if err := val.DecodeSSZ(raw, int(version)); err != nil {
+		return fmt.Errorf("unable to unmarshal message: %w", err)
+	}
+	return nil
+}
+
 func ReadUvarint(r io.Reader) (x, n uint64, err error) {
 	currByte := make([]byte, 1)
 	for shift := uint(0); shift < 64; shift += 7 {
@@ -0,0 +1,50 @@
+package ssz_snappy
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes"
+)
+
+func TestEncodeAndReadNoForkDigest(t *testing.T) {
+	exit := &cltypes.SignedVoluntaryExit{VoluntaryExit: &cltypes.VoluntaryExit{Epoch: 1, ValidatorIndex: 2}, Signature: [96]byte{3}}
+
+	var buf bytes.Buffer
+	require.NoError(t, EncodeAndWrite(&buf, exit))
+	decoded := &cltypes.SignedVoluntaryExit{}
+	require.NoError(t, DecodeAndReadNoForkDigest(&buf, decoded, clparams.Phase0Version))
+	require.Equal(t, exit, decoded)
+}
+
+func TestLengthPrefixed(t *testing.T) {
+	depositData := &cltypes.DepositData{
+		PubKey:                [48]byte{1, 2, 3},
+		WithdrawalCredentials: [32]byte{4, 5, 6},
+		Amount:                32000000000,
+		Signature:             [96]byte{7, 8, 9},
+	}
+	var buf bytes.Buffer
+	require.NoError(t, WriteLengthPrefixed(&buf, depositData))
+	// 184 as a uvarint, then the SSZ encoding.
+	require.Equal(t, []byte{0xb8, 0x01}, buf.Bytes()[:2])
+	require.Equal(t, 2+depositData.EncodingSizeSSZ(), buf.Len())
+
+	decoded := &cltypes.DepositData{}
+	require.NoError(t, ReadLengthPrefixed(&buf, decoded, uint64(decoded.EncodingSizeSSZ()), clparams.Phase0Version))
+	require.Equal(t, depositData, decoded)
+
+	// A declared length over the limit is rejected before reading the body.
+	require.NoError(t, WriteLengthPrefixed(&buf, depositData))
+	require.ErrorContains(t, ReadLengthPrefixed(&buf, decoded, 183, clparams.Phase0Version), "payload too big")
+	require.ErrorContains(t, ReadLengthPrefixed(bytes.NewReader(binary.AppendUvarint(nil, 1<<40)), decoded, 1<<20, clparams.Phase0Version), "payload too big")
+
+	// A truncated body.
+	buf.Reset()
+	require.NoError(t, WriteLengthPrefixed(&buf, depositData))
+	require.Error(t, ReadLengthPrefixed(bytes.NewReader(buf.Bytes()[:100]), decoded, 1<<20, clparams.Phase0Version))
+}
@@ -199,9 +199,11 @@ func TestLightClientBootstrap(t *testing.T) {
 		NextSyncCommitteeBranch: solid.NewHashVector(cltypes.SyncCommitteeBranchSize),
 	}
 	reqRoot := common.Hash{1, 2, 3}
+	committee := make([]common.Bytes48, cltypes.SyncCommitteeSize)
+	committee[0] = common.Bytes48{1, 2, 3, 5, 6}
 	f.LightClientBootstraps[reqRoot] = &cltypes.LightClientBootstrap{
 		Header:                     cltypes.NewLightClientHeader(clparams.AltairVersion),
-		CurrentSyncCommittee:       &solid.SyncCommittee{1, 2, 3, 5, 6},
+		CurrentSyncCommittee:       solid.NewSyncCommitteeFromParameters(committee, common.Bytes48{}),
 		CurrentSyncCommitteeBranch: solid.NewHashVector(cltypes.SyncCommitteeBranchSize),
 	}
 	genesisCfg, _, beaconCfg := clparams.GetConfigsByNetwork(1)
@@ -272,7 +274,7 @@ func TestLightClientUpdates(t *testing.T) {
 
 	up := &cltypes.LightClientUpdate{
 		AttestedHeader:    cltypes.NewLightClientHeader(clparams.AltairVersion),
-		NextSyncCommittee: &solid.SyncCommittee{},
+		NextSyncCommittee: solid.NewSyncCommittee(cltypes.SyncCommitteeSize),
 		SignatureSlot:     1234,
 		SyncAggregate:     &cltypes.SyncAggregate{},
 		FinalizedHeader:   cltypes.NewLightClientHeader(clparams.AltairVersion),
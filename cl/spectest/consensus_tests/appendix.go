@@ -99,13 +99,13 @@ func addSszTests() {
 		With("ContributionAndProof", getSSZStaticConsensusTest(&cltypes.ContributionAndProof{})).
 		With("Deposit", getSSZStaticConsensusTest(&cltypes.Deposit{})).
 		With("DepositData", getSSZStaticConsensusTest(&cltypes.DepositData{})).
-		//	With("DepositMessage", getSSZStaticConsensusTest(&cltypes.DepositMessage{})).
+		With("DepositMessage", getSSZStaticConsensusTest(&cltypes.DepositMessage{})).
 		// With("Eth1Block", getSSZStaticConsensusTest(&cltypes.Eth1Block{})).
 		With("Eth1Data", getSSZStaticConsensusTest(&cltypes.Eth1Data{})).
 		With("ExecutionPayload", getSSZStaticConsensusTest(cltypes.NewEth1Block(clparams.Phase0Version, &clparams.MainnetBeaconConfig))).
 		//With("ExecutionPayloadHeader", getSSZStaticConsensusTest(&cltypes.Eth1Header{})).
 		With("Fork", getSSZStaticConsensusTest(&cltypes.Fork{})).
-		//With("ForkData", getSSZStaticConsensusTest(&cltypes.ForkData{})).
+		With("ForkData", getSSZStaticConsensusTest(&cltypes.ForkData{})).
 		//With("HistoricalBatch", getSSZStaticConsensusTest(&cltypes.HistoricalBatch{})).
 		With("HistoricalSummary", getSSZStaticConsensusTest(&cltypes.HistoricalSummary{})).
 		With("IndexedAttestation", getSSZStaticConsensusTest(&cltypes.IndexedAttestation{})).
@@ -130,7 +130,7 @@ func addSszTests() {
 		With("SyncCommittee", getSSZStaticConsensusTest(&solid.SyncCommittee{})).
 		//	With("SyncCommitteeContribution", getSSZStaticConsensusTest(&cltypes.SyncCommitteeContribution{})).
 		//	With("SyncCommitteeMessage", getSSZStaticConsensusTest(&cltypes.SyncCommitteeMessage{})).
-		With("Validator", getSSZStaticConsensusTest(solid.NewValidator()))
-	// With("VoluntaryExit", getSSZStaticConsensusTest(&cltypes.VoluntaryExit{})) TODO
+		With("Validator", getSSZStaticConsensusTest(solid.NewValidator())).
+		With("VoluntaryExit", getSSZStaticConsensusTest(&cltypes.VoluntaryExit{}))
 	// With("Withdrawal", getSSZStaticConsensusTest(&types.Withdrawal{})) TODO
 }
@@ -37,6 +37,31 @@ types such as uint64, []byte, and objects that implement the SizedObjectSSZ inte
 It handles both static (fixed size) and dynamic (variable size) objects based on their respective decoding methods and offsets.
 */
 func UnmarshalSSZ(buf []byte, version int, schema ...interface{}) (err error) {
+	return unmarshalSSZ(buf, version, false, schema...)
+}
+
+// StrictUnmarshaler is implemented by objects that can reject non canonical encodings which DecodeSSZ
+// tolerates, such as bitlists without their delimiter bit.
+type StrictUnmarshaler interface {
+	DecodeSSZStrict(buf []byte, version int) error
+}
+
+// UnmarshalSSZStrict is UnmarshalSSZ for untrusted input (e.g. from the network): it also requires the first
+// offset to point right after the fixed size part, and decodes the elements implementing StrictUnmarshaler
+// with DecodeSSZStrict.
+func UnmarshalSSZStrict(buf []byte, version int, schema ...interface{}) error {
+	return unmarshalSSZ(buf, version, true, schema...)
+}
+
+// decodeElement decodes obj from buf, strictly if asked to and obj supports it.
+func decodeElement(obj SizedObjectSSZ, buf []byte, version int, strict bool) error {
+	if strictObj, ok := obj.(StrictUnmarshaler); ok && strict {
+		return strictObj.DecodeSSZStrict(buf, version)
+	}
+	return obj.DecodeSSZ(buf, version)
+}
+
+func unmarshalSSZ(buf []byte, version int, strict bool, schema ...interface{}) (err error) {
 	// defer func() {
 	// 	if err2 := recover(); err2 != nil {
 	// 		err = fmt.Errorf("panic while decoding: %v", err2)
@@ -51,14 +76,14 @@ func UnmarshalSSZ(buf []byte, version int, schema ...interface{}) (err error) {
 		switch obj := element.(type) {
 		case *uint64:
 			if len(buf) < position+8 {
-				return ssz.ErrLowBufferSize
+				return fmt.Errorf("element %d: %w", i, ssz.ErrLowBufferSize)
 			}
 			// If the element is a pointer to uint64, decode it from the buf using little-endian encoding
 			*obj = binary.LittleEndian.Uint64(buf[position:])
 			position += 8
 		case []byte:
 			if len(buf) < position+len(obj) {
-				return ssz.ErrLowBufferSize
+				return fmt.Errorf("element %d: %w", i, ssz.ErrLowBufferSize)
 			}
 			// If the element is a byte slice, copy the corresponding data from the buf to the slice
 			copy(obj, buf[position:])
@@ -66,17 +91,18 @@ func UnmarshalSSZ(buf []byte, version int, schema ...interface{}) (err error) {
 		case SizedObjectSSZ:
 			// If the element implements the SizedObjectSSZ interface
 			if obj.Static() {
-				if len(buf) < position+obj.EncodingSizeSSZ() {
-					return ssz.ErrLowBufferSize
+				size := obj.EncodingSizeSSZ()
+				if len(buf) < position+size {
+					return fmt.Errorf("static element %d/%s: %w", i, reflect.TypeOf(obj), ssz.ErrLowBufferSize)
 				}
-				// If the object is static (fixed size), decode it from the buf and update the position
-				if err = obj.DecodeSSZ(buf[position:], version); err != nil {
-					return fmt.Errorf("static element %d: %w", i, err)
+				// If the object is static (fixed size), decode it from exactly its share of the buf and update the position
+				if err = decodeElement(obj, buf[position:position+size], version, strict); err != nil {
+					return fmt.Errorf("static element %d/%s: %w", i, reflect.TypeOf(obj), err)
 				}
-				position += obj.EncodingSizeSSZ()
+				position += size
 			} else {
 				if len(buf) < position+4 {
-					return ssz.ErrLowBufferSize
+					return fmt.Errorf("dynamic element %d/%s: %w", i, reflect.TypeOf(obj), ssz.ErrLowBufferSize)
 				}
 				// If the object is dynamic (variable size), store the offset and the object in separate slices
 				offsets = append(offsets, int(binary.LittleEndian.Uint32(buf[position:])))
@@ -90,6 +116,15 @@ func UnmarshalSSZ(buf []byte, version int, schema ...interface{}) (err error) {
 		}
 	}
 
+	// A fixed size schema must span the whole buffer.
+	if len(dynamicObjs) == 0 && len(buf) > position {
+		return fmt.Errorf("%d bytes past the end of the schema: %w", len(buf)-position, ssz.ErrBufferTooLong)
+	}
+	// In a canonical encoding the dynamic part starts right after the fixed part.
+	if strict && len(dynamicObjs) > 0 && offsets[0] != position {
+		return fmt.Errorf("dynamic element 0/%s: first offset %d, expected %d: %w", reflect.TypeOf(dynamicObjs[0]), offsets[0], position, ssz.ErrBadOffset)
+	}
+
 	// Iterate over the dynamic objects and decode them using the stored offsets
 	for i, obj := range dynamicObjs {
 		endOffset := len(buf)
@@ -97,12 +132,13 @@ func UnmarshalSSZ(buf []byte, version int, schema ...interface{}) (err error) {
 			endOffset = offsets[i+1]
 		}
 		if offsets[i] > endOffset {
-			return ssz.ErrBadOffset
+			return fmt.Errorf("dynamic element %d/%s: %w", i, reflect.TypeOf(obj), ssz.ErrBadOffset)
 		}
 		if len(buf) < endOffset {
-			return ssz.ErrLowBufferSize
+			// The next offset points past the end of the buffer.
+			return fmt.Errorf("dynamic element %d/%s: %w", i, reflect.TypeOf(obj), ssz.ErrBadOffset)
 		}
-		if err = obj.DecodeSSZ(buf[offsets[i]:endOffset], version); err != nil {
+		if err = decodeElement(obj, buf[offsets[i]:endOffset], version, strict); err != nil {
 			return fmt.Errorf("dynamic element (sz:%d) %d/%s: %w", endOffset-offsets[i], i, reflect.TypeOf(obj), err)
 		}
 	}
@@ -68,11 +68,11 @@ func MarshalSSZ(buf []byte, schema ...any) (dst []byte, err error) {
 		switch obj := element.(type) {
 		case uint64:
 			// If the element is a uint64, encode it using SSZ and append it to the dst
-			dst = append(dst, ssz.Uint64SSZ(obj)...)
+			dst = ssz.AppendUint64SSZ(dst, obj)
 			currentOffset += 8
 		case *uint64:
 			// If the element is a pointer to uint64, dereference it, encode it using SSZ, and append it to the dst
-			dst = append(dst, ssz.Uint64SSZ(*obj)...)
+			dst = ssz.AppendUint64SSZ(dst, *obj)
 			currentOffset += 8
 		case []byte:
 			// If the element is a byte slice, append it to the dst
@@ -0,0 +1,49 @@
+package ssz2
+
+import (
+	"sync"
+
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
+)
+
+const (
+	// pooledBufferSize fits the bulk of gossip messages, the sync committee (24624 bytes) included.
+	pooledBufferSize = 32 * 1024
+	// maxPooledBufferSize avoids holding onto huge buffers, such as beacon state encodings.
+	maxPooledBufferSize = 1024 * 1024
+)
+
+var buffersPool = sync.Pool{
+	New: func() interface{} {
+		b := make([]byte, 0, pooledBufferSize)
+		return &b
+	},
+}
+
+// GetBuffer returns an empty buffer from the pool.
+func GetBuffer() *[]byte {
+	buf := buffersPool.Get().(*[]byte)
+	*buf = (*buf)[:0]
+	return buf
+}
+
+// PutBuffer releases buf to the pool. Neither buf nor anything sliced from it may be used afterwards,
+// so callers must copy out whatever they want to keep before releasing it.
+func PutBuffer(buf *[]byte) {
+	if cap(*buf) > maxPooledBufferSize {
+		return
+	}
+	buffersPool.Put(buf)
+}
+
+// EncodeSSZPooled encodes obj into a buffer drawn from the pool. The buffer must be released with PutBuffer.
+func EncodeSSZPooled(obj ssz.Marshaler) (*[]byte, error) {
+	buf := GetBuffer()
+	encoded, err := obj.EncodeSSZ(*buf)
+	if err != nil {
+		PutBuffer(buf)
+		return nil, err
+	}
+	*buf = encoded
+	return buf, nil
+}
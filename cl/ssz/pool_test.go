@@ -0,0 +1,41 @@
+package ssz2_test
+
+import (
+	"testing"
+
+	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
+	ssz2 "github.com/ledgerwatch/erigon/cl/ssz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeSSZPooled(t *testing.T) {
+	committee := solid.NewSyncCommitteeFromParameters(make([]common.Bytes48, 512), common.Bytes48{1})
+	expected, err := committee.EncodeSSZ(nil)
+	require.NoError(t, err)
+
+	buf, err := ssz2.EncodeSSZPooled(committee)
+	require.NoError(t, err)
+	require.Equal(t, expected, *buf)
+	ssz2.PutBuffer(buf)
+
+	// A buffer coming back from the pool is always empty.
+	require.Empty(t, *ssz2.GetBuffer())
+}
+
+func BenchmarkEncodeSyncCommittee(b *testing.B) {
+	committee := solid.NewSyncCommitteeFromParameters(make([]common.Bytes48, 512), common.Bytes48{1})
+	b.Run("alloc", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			committee.EncodeSSZ(nil)
+		}
+	})
+	b.Run("pooled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			buf, _ := ssz2.EncodeSSZPooled(committee)
+			ssz2.PutBuffer(buf)
+		}
+	})
+}
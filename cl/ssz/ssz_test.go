@@ -5,8 +5,11 @@ import (
 	"testing"
 
 	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
 	"github.com/ledgerwatch/erigon/cl/clparams"
+	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
 	"github.com/ledgerwatch/erigon/cl/phase1/core/state"
+	ssz2 "github.com/ledgerwatch/erigon/cl/ssz"
 	"github.com/ledgerwatch/erigon/cl/utils"
 	"github.com/stretchr/testify/require"
 )
@@ -25,3 +28,122 @@ func TestEncodeDecode(t *testing.T) {
 	dec, _ := utils.DecompressSnappy(beaconState)
 	require.Equal(t, dec, d)
 }
+
+func TestUnmarshalSSZErrorContext(t *testing.T) {
+	var a, b uint64
+	err := ssz2.UnmarshalSSZ(make([]byte, 12), 0, &a, &b)
+	require.ErrorIs(t, err, ssz.ErrLowBufferSize)
+	require.Contains(t, err.Error(), "element 1")
+
+	// Nested failures carry the type of the element that failed.
+	err = ssz2.UnmarshalSSZ(make([]byte, 8), 0, &a, solid.NewHashVector(2))
+	require.ErrorIs(t, err, ssz.ErrLowBufferSize)
+	require.Contains(t, err.Error(), "static element 1/*solid.hashVector")
+}
+
+func TestUnmarshalSSZErrors(t *testing.T) {
+	newSchema := func() []interface{} {
+		var a uint64
+		return []interface{}{&a, solid.NewUint64ListSSZ(8), solid.NewUint64ListSSZ(8)}
+	}
+	encoded := []byte{
+		1, 0, 0, 0, 0, 0, 0, 0, // a
+		16, 0, 0, 0, // offset of the first list
+		24, 0, 0, 0, // offset of the second list
+		2, 0, 0, 0, 0, 0, 0, 0, // first list
+		3, 0, 0, 0, 0, 0, 0, 0, // second list
+	}
+	require.NoError(t, ssz2.UnmarshalSSZ(encoded, 0, newSchema()...))
+
+	outOfOrder := common.CopyBytes(encoded)
+	outOfOrder[8], outOfOrder[12] = 24, 16
+	pastTheEnd := common.CopyBytes(encoded)
+	pastTheEnd[12] = 40
+	overLimit := append(common.CopyBytes(encoded), make([]byte, 8*8)...)
+
+	tests := []struct {
+		name   string
+		buf    []byte
+		schema []interface{}
+		err    error
+	}{
+		{name: "truncated fixed part", buf: encoded[:10], schema: newSchema(), err: ssz.ErrLowBufferSize},
+		{name: "offsets out of order", buf: outOfOrder, schema: newSchema(), err: ssz.ErrBadOffset},
+		{name: "offset past the end", buf: pastTheEnd, schema: newSchema(), err: ssz.ErrBadOffset},
+		{name: "list over its limit", buf: overLimit, schema: newSchema(), err: ssz.ErrTooBigList},
+		{name: "trailing bytes", buf: append(make([]byte, 16), 0), schema: []interface{}{new(uint64), new(uint64)}, err: ssz.ErrBufferTooLong},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.ErrorIs(t, ssz2.UnmarshalSSZ(tt.buf, 0, tt.schema...), tt.err)
+		})
+	}
+}
+
+func TestMarshalSSZMixedContainer(t *testing.T) {
+	// A container of two fixed size fields and two variable size fields, interleaved.
+	a, b := uint64(1), [4]byte{2, 3, 4, 5}
+	first, second := solid.NewUint64ListSSZ(8), solid.NewUint64ListSSZ(8)
+	first.Append(6)
+	first.Append(7)
+	second.Append(8)
+
+	encoded, err := ssz2.MarshalSSZ(nil, a, first, b[:], second)
+	require.NoError(t, err)
+	require.Equal(t, []byte{
+		1, 0, 0, 0, 0, 0, 0, 0, // a
+		20, 0, 0, 0, // offset of first, right after the 8+4+4+4 bytes fixed part
+		2, 3, 4, 5, // b
+		36, 0, 0, 0, // offset of second, after the two elements of first
+		6, 0, 0, 0, 0, 0, 0, 0, // first
+		7, 0, 0, 0, 0, 0, 0, 0,
+		8, 0, 0, 0, 0, 0, 0, 0, // second
+	}, encoded)
+
+	var decodedA uint64
+	var decodedB [4]byte
+	decodedFirst, decodedSecond := solid.NewUint64ListSSZ(8), solid.NewUint64ListSSZ(8)
+	require.NoError(t, ssz2.UnmarshalSSZ(encoded, 0, &decodedA, decodedFirst, decodedB[:], decodedSecond))
+	require.Equal(t, a, decodedA)
+	require.Equal(t, b, decodedB)
+	require.Equal(t, 2, decodedFirst.Length())
+	require.Equal(t, uint64(7), decodedFirst.Get(1))
+	require.Equal(t, 1, decodedSecond.Length())
+	require.Equal(t, uint64(8), decodedSecond.Get(0))
+
+	// Both variable size fields may be empty, their offsets then point to the end of the buffer.
+	encoded, err = ssz2.MarshalSSZ(nil, a, solid.NewUint64ListSSZ(8), b[:], solid.NewUint64ListSSZ(8))
+	require.NoError(t, err)
+	require.Len(t, encoded, 20)
+	require.NoError(t, ssz2.UnmarshalSSZ(encoded, 0, &decodedA, decodedFirst, decodedB[:], decodedSecond))
+	require.Zero(t, decodedFirst.Length())
+	require.Zero(t, decodedSecond.Length())
+}
+
+func TestUnmarshalSSZStrict(t *testing.T) {
+	a, b := uint64(1), [4]byte{2, 3, 4, 5}
+	first, second := solid.NewUint64ListSSZ(8), solid.NewUint64ListSSZ(8)
+	first.Append(6)
+	second.Append(7)
+	encoded, err := ssz2.MarshalSSZ(nil, a, first, b[:], second)
+	require.NoError(t, err)
+
+	decode := func(buf []byte, strict bool) error {
+		var decodedA uint64
+		var decodedB [4]byte
+		schema := []interface{}{&decodedA, solid.NewUint64ListSSZ(8), decodedB[:], solid.NewUint64ListSSZ(8)}
+		if strict {
+			return ssz2.UnmarshalSSZStrict(buf, 0, schema...)
+		}
+		return ssz2.UnmarshalSSZ(buf, 0, schema...)
+	}
+	require.NoError(t, decode(encoded, false))
+	require.NoError(t, decode(encoded, true))
+
+	// Eight bytes of garbage between the fixed and the variable parts, skipped by the offsets.
+	gap := append(append(append([]byte{}, encoded[:20]...), make([]byte, 8)...), encoded[20:]...)
+	gap[8] += 8
+	gap[16] += 8
+	require.NoError(t, decode(gap, false))
+	require.ErrorIs(t, decode(gap, true), ssz.ErrBadOffset)
+}
@@ -0,0 +1,66 @@
+package ssz2
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// EncoderTo is implemented by objects which can write their SSZ encoding to a writer
+// without building the whole encoding in memory first.
+type EncoderTo interface {
+	EncodeSSZTo(w io.Writer) (int, error)
+}
+
+/*
+MarshalSSZTo writes the SSZ encoding of the schema to w and returns the number of bytes written.
+The schema follows the same conventions as MarshalSSZ, and the output is byte for byte identical.
+
+Primitives and byte slices are written directly. Static objects implementing EncoderTo are streamed,
+other static objects are encoded into a buffer of their own size before being written.
+Dynamic objects are not supported, as their offsets need the size of the whole fixed part upfront.
+*/
+func MarshalSSZTo(w io.Writer, schema ...any) (n int, err error) {
+	defer func() {
+		if err2 := recover(); err2 != nil {
+			err = fmt.Errorf("panic while encoding: %v", err2)
+		}
+	}()
+
+	var (
+		written int
+		scratch [8]byte
+	)
+	for i, element := range schema {
+		switch obj := element.(type) {
+		case uint64:
+			binary.LittleEndian.PutUint64(scratch[:], obj)
+			written, err = w.Write(scratch[:])
+		case *uint64:
+			binary.LittleEndian.PutUint64(scratch[:], *obj)
+			written, err = w.Write(scratch[:])
+		case []byte:
+			written, err = w.Write(obj)
+		case SizedObjectSSZ:
+			if !obj.Static() {
+				return n, fmt.Errorf("dynamic schema component %d cannot be streamed", i)
+			}
+			if encoder, ok := obj.(EncoderTo); ok {
+				written, err = encoder.EncodeSSZTo(w)
+				break
+			}
+			var buf []byte
+			if buf, err = obj.EncodeSSZ(make([]byte, 0, obj.EncodingSizeSSZ())); err != nil {
+				return n, err
+			}
+			written, err = w.Write(buf)
+		default:
+			return n, fmt.Errorf("bad schema component %d: %T", i, element)
+		}
+		n += written
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
@@ -0,0 +1,25 @@
+package ssz2_test
+
+import (
+	"bytes"
+	"testing"
+
+	ssz2 "github.com/ledgerwatch/erigon/cl/ssz"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarshalSSZTo(t *testing.T) {
+	x := uint64(7)
+	schema := []any{uint64(1), &x, []byte{2, 3, 4}}
+
+	expected, err := ssz2.MarshalSSZ(nil, schema...)
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	n, err := ssz2.MarshalSSZTo(&buf, schema...)
+	require.NoError(t, err)
+	require.Equal(t, len(expected), n)
+	require.Equal(t, expected, buf.Bytes())
+
+	_, err = ssz2.MarshalSSZTo(&buf, "unsupported")
+	require.Error(t, err)
+}
@@ -38,7 +38,7 @@ func (I *impl) ProcessProposerSlashing(s abstract.BeaconState, propSlashing *clt
 		return fmt.Errorf("non-matching proposer indices proposer slashing: %d != %d", h1.ProposerIndex, h2.ProposerIndex)
 	}
 
-	if *h1 == *h2 {
+	if h1.Equal(h2) {
 		return fmt.Errorf("proposee slashing headers are the same")
 	}
 
@@ -132,26 +132,17 @@ func (I *impl) ProcessDeposit(s abstract.BeaconState, deposit *cltypes.Deposit)
 	if deposit == nil {
 		return nil
 	}
-	depositLeaf, err := deposit.Data.HashSSZ()
-	if err != nil {
-		return err
-	}
 	depositIndex := s.Eth1DepositIndex()
 	eth1Data := s.Eth1Data()
-	rawProof := []common.Hash{}
-	deposit.Proof.Range(func(_ int, l common.Hash, _ int) bool {
-		rawProof = append(rawProof, l)
-		return true
-	})
 	// Validate merkle proof for deposit leaf.
-	if I.FullValidation && !utils.IsValidMerkleBranch(
-		depositLeaf,
-		rawProof,
-		s.BeaconConfig().DepositContractTreeDepth+1,
-		depositIndex,
-		eth1Data.Root,
-	) {
-		return fmt.Errorf("processDepositForAltair: Could not validate deposit root")
+	if I.FullValidation {
+		valid, err := deposit.VerifyProofWithDepth(s.BeaconConfig().DepositContractTreeDepth, depositIndex, eth1Data.Root)
+		if err != nil {
+			return err
+		}
+		if !valid {
+			return fmt.Errorf("processDepositForAltair: Could not validate deposit root")
+		}
 	}
 
 	// Increment index
@@ -166,13 +157,8 @@ func (I *impl) ProcessDeposit(s abstract.BeaconState, deposit *cltypes.Deposit)
 		if err != nil {
 			return err
 		}
-		depositMessageRoot, err := deposit.Data.MessageHash()
-		if err != nil {
-			return err
-		}
-		signedRoot := utils.Sha256(depositMessageRoot[:], domain)
 		// Perform BLS verification and if successful noice.
-		valid, err := bls.Verify(deposit.Data.Signature[:], signedRoot[:], publicKey[:])
+		valid, err := deposit.Data.VerifyDepositSignature(common.BytesToHash(domain))
 		// Literally you can input it trash.
 		if !valid || err != nil {
 			log.Debug("Validator BLS verification failed", "valid", valid, "err", err)
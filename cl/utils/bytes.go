@@ -57,6 +57,8 @@ func CompressSnappy(data []byte) []byte {
 	return snappy.Encode(nil, data)
 }
 
+// EncodeSSZSnappy encodes data to SSZ and compresses it with the snappy block format, as used for gossip messages.
+// Req/resp payloads use the snappy frame format instead, see the ssz_snappy package of the sentinel.
 func EncodeSSZSnappy(data ssz.Marshaler) ([]byte, error) {
 	var (
 		enc = make([]byte, 0, data.EncodingSizeSSZ())
@@ -70,6 +72,7 @@ func EncodeSSZSnappy(data ssz.Marshaler) ([]byte, error) {
 	return snappy.Encode(nil, enc), nil
 }
 
+// DecodeSSZSnappy decompresses a snappy block formatted src and decodes the SSZ result into dst.
 func DecodeSSZSnappy(dst ssz.Unmarshaler, src []byte, version int) error {
 	dec, err := snappy.Decode(nil, src)
 	if err != nil {
@@ -4,8 +4,10 @@ import (
 	"testing"
 
 	"github.com/ledgerwatch/erigon-lib/common"
+	"github.com/ledgerwatch/erigon-lib/types/ssz"
 
 	"github.com/ledgerwatch/erigon/cl/cltypes"
+	"github.com/ledgerwatch/erigon/cl/cltypes/solid"
 	"github.com/ledgerwatch/erigon/cl/utils"
 	"github.com/stretchr/testify/require"
 )
@@ -36,3 +38,39 @@ func TestLiteralConverters(t *testing.T) {
 	require.Equal(t, utils.BytesToBytes4([]byte{10, 23, 56, 7, 8, 5}), [4]byte{10, 23, 56, 7})
 	require.Equal(t, utils.Uint64ToLE(600), []byte{0x58, 0x2, 0x0, 0x0, 0x0, 0x0, 0x00, 0x00})
 }
+
+func TestSSZSnappyRoundTrip(t *testing.T) {
+	proof := solid.NewHashVector(cltypes.DepositProofLength)
+	proof.Set(3, common.Hash{3})
+	depositData := &cltypes.DepositData{PubKey: [48]byte{1}, WithdrawalCredentials: [32]byte{2}, Amount: 32000000000, Signature: [96]byte{3}}
+	committee := &solid.SyncCommittee{}
+	committee.SetAggregatePublicKey([48]byte{9})
+
+	tests := []struct {
+		name    string
+		obj     ssz.EncodableSSZ
+		decoded ssz.EncodableSSZ
+	}{
+		{name: "DepositMessage", obj: depositData.Message(), decoded: &cltypes.DepositMessage{}},
+		{name: "DepositData", obj: depositData, decoded: &cltypes.DepositData{}},
+		{name: "Deposit", obj: &cltypes.Deposit{Proof: proof, Data: depositData}, decoded: &cltypes.Deposit{}},
+		{name: "VoluntaryExit", obj: &cltypes.VoluntaryExit{Epoch: 1, ValidatorIndex: 2}, decoded: &cltypes.VoluntaryExit{}},
+		{name: "SignedVoluntaryExit", obj: &cltypes.SignedVoluntaryExit{VoluntaryExit: &cltypes.VoluntaryExit{Epoch: 1, ValidatorIndex: 2}, Signature: [96]byte{4}}, decoded: &cltypes.SignedVoluntaryExit{}},
+		{name: "Eth1Data", obj: &cltypes.Eth1Data{Root: common.Hash{5}, DepositCount: 6, BlockHash: common.Hash{7}}, decoded: &cltypes.Eth1Data{}},
+		{name: "Fork", obj: &cltypes.Fork{PreviousVersion: [4]byte{1}, CurrentVersion: [4]byte{2}, Epoch: 3}, decoded: &cltypes.Fork{}},
+		{name: "ForkData", obj: &cltypes.ForkData{CurrentVersion: [4]byte{2}, GenesisValidatorsRoot: common.Hash{8}}, decoded: &cltypes.ForkData{}},
+		{name: "SyncCommittee", obj: committee, decoded: &solid.SyncCommittee{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			expected, err := tt.obj.EncodeSSZ(nil)
+			require.NoError(t, err)
+			encoded, err := utils.EncodeSSZSnappy(tt.obj)
+			require.NoError(t, err)
+			require.NoError(t, utils.DecodeSSZSnappy(tt.decoded, encoded, 0))
+			decoded, err := tt.decoded.EncodeSSZ(nil)
+			require.NoError(t, err)
+			require.Equal(t, expected, decoded)
+		})
+	}
+}
@@ -20,8 +20,10 @@ import "errors"
 
 var (
 	ErrLowBufferSize    = errors.New("ssz(DecodeSSZ): bad encoding size")
+	ErrBufferTooLong    = errors.New("ssz(DecodeSSZ): buffer too long")
 	ErrBadDynamicLength = errors.New("ssz(DecodeSSZ): bad dynamic length")
 	ErrBadOffset        = errors.New("ssz(DecodeSSZ): invalid offset")
 	ErrBufferNotRounded = errors.New("ssz(DecodeSSZ): badly rounded operator")
 	ErrTooBigList       = errors.New("ssz(DecodeSSZ): list too big")
+	ErrNonCanonical     = errors.New("ssz(DecodeSSZ): non canonical encoding")
 )
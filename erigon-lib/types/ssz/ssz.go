@@ -18,6 +18,7 @@ package ssz
 
 import (
 	"encoding/binary"
+	"math/bits"
 
 	"github.com/ledgerwatch/erigon-lib/common"
 	"github.com/ledgerwatch/erigon-lib/common/length"
@@ -38,6 +39,9 @@ type EncodableSSZ interface {
 	Unmarshaler
 }
 
+// Marshaler is implemented by SSZ encodable objects. EncodeSSZ appends the encoding to the given buffer and
+// returns the extended buffer, it must not retain the buffer nor return memory owned by the object, so that
+// callers may reuse both the input and the returned buffer (e.g. from a pool) once the call returns.
 type Marshaler interface {
 	EncodeSSZ([]byte) ([]byte, error)
 	EncodingSizeSSZ() int
@@ -52,6 +56,11 @@ func MarshalUint64SSZ(buf []byte, x uint64) {
 	binary.LittleEndian.PutUint64(buf, x)
 }
 
+// AppendUint64SSZ appends the SSZ encoding of x to dst, without the temporary buffer of Uint64SSZ.
+func AppendUint64SSZ(dst []byte, x uint64) []byte {
+	return binary.LittleEndian.AppendUint64(dst, x)
+}
+
 func Uint64SSZ(x uint64) []byte {
 	b := make([]byte, 8)
 	binary.LittleEndian.PutUint64(b, x)
@@ -85,6 +94,25 @@ func UnmarshalUint64SSZ(x []byte) uint64 {
 	return binary.LittleEndian.Uint64(x)
 }
 
+// CheckListLimit returns ErrTooBigList if a list declares more elements than its SSZ limit.
+// Decoders of variable-length types call it before allocating anything for the elements.
+func CheckListLimit(count, limit uint64) error {
+	if count > limit {
+		return ErrTooBigList
+	}
+	return nil
+}
+
+// CheckBitlist returns ErrNonCanonical if buf is not a canonical bitlist encoding, whose last byte holds the
+// delimiter bit right after the last bit of the list, and ErrTooBigList if the list has more than limit bits.
+func CheckBitlist(buf []byte, limit uint64) error {
+	if len(buf) == 0 || buf[len(buf)-1] == 0 {
+		return ErrNonCanonical
+	}
+	bitLength := uint64(len(buf)-1)*8 + uint64(bits.Len8(buf[len(buf)-1])) - 1
+	return CheckListLimit(bitLength, limit)
+}
+
 func DecodeDynamicList[T Unmarshaler](bytes []byte, start, end uint32, max uint64, version int) ([]T, error) {
 	if start > end || len(bytes) < int(end) {
 		return nil, ErrBadOffset
@@ -96,8 +124,8 @@ func DecodeDynamicList[T Unmarshaler](bytes []byte, start, end uint32, max uint6
 		elementsNum = currentOffset / 4
 	}
 	inPos := 4
-	if uint64(elementsNum) > max {
-		return nil, ErrTooBigList
+	if err := CheckListLimit(uint64(elementsNum), max); err != nil {
+		return nil, err
 	}
 	objs := make([]T, elementsNum)
 	for i := range objs {
@@ -131,13 +159,13 @@ func DecodeStaticList[T Unmarshaler](bytes []byte, start, end, bytesPerElement u
 	if uint32(len(buf))%bytesPerElement != 0 {
 		return nil, ErrBufferNotRounded
 	}
-	if elementsNum > max {
-		return nil, ErrTooBigList
+	if err := CheckListLimit(elementsNum, max); err != nil {
+		return nil, err
 	}
 	objs := make([]T, elementsNum)
 	for i := range objs {
 		objs[i] = objs[i].Clone().(T)
-		if err := objs[i].DecodeSSZ(buf[i*int(bytesPerElement):], version); err != nil {
+		if err := objs[i].DecodeSSZ(buf[i*int(bytesPerElement):(i+1)*int(bytesPerElement)], version); err != nil {
 			return nil, err
 		}
 	}
@@ -154,8 +182,8 @@ func DecodeHashList(bytes []byte, start, end, max uint32) ([]common.Hash, error)
 	if uint32(len(buf))%length.Hash != 0 {
 		return nil, ErrBufferNotRounded
 	}
-	if elementsNum > max {
-		return nil, ErrTooBigList
+	if err := CheckListLimit(uint64(elementsNum), uint64(max)); err != nil {
+		return nil, err
 	}
 	objs := make([]common.Hash, elementsNum)
 	for i := range objs {
@@ -174,8 +202,8 @@ func DecodeNumbersList(bytes []byte, start, end uint32, max uint64) ([]uint64, e
 	if uint64(len(buf))%length.BlockNum != 0 {
 		return nil, ErrBufferNotRounded
 	}
-	if elementsNum > max {
-		return nil, ErrTooBigList
+	if err := CheckListLimit(elementsNum, max); err != nil {
+		return nil, err
 	}
 	objs := make([]uint64, elementsNum)
 	for i := range objs {
@@ -200,8 +228,8 @@ func DecodeString(bytes []byte, start, end, max uint64) ([]byte, error) {
 		return nil, ErrBadOffset
 	}
 	buf := bytes[start:end]
-	if uint64(len(buf)) > max {
-		return nil, ErrTooBigList
+	if err := CheckListLimit(uint64(len(buf)), max); err != nil {
+		return nil, err
 	}
 	return buf, nil
 }